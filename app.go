@@ -3,8 +3,12 @@ package main
 import (
 	"context"
 	"go-wails/internal/api"
+	"go-wails/internal/events"
 	"go-wails/internal/models"
 	"go-wails/internal/service"
+	"path/filepath"
+
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
 // App struct
@@ -17,8 +21,8 @@ type App struct {
 
 // NewApp creates a new App application struct
 func NewApp() *App {
-	xmrigService := service.NewXMRigService()
 	configService := service.NewConfigService()
+	xmrigService := service.NewXMRigService(configService)
 
 	return &App{
 		xmrigService:  xmrigService,
@@ -31,12 +35,14 @@ func NewApp() *App {
 // so we can call the runtime methods
 func (a *App) startup(ctx context.Context) {
 	a.ctx = ctx
-	a.xmrigService.SetContext(ctx)
+	a.xmrigService.Events().Subscribe(events.SinkFunc(func(e events.Event) {
+		wailsruntime.EventsEmit(ctx, e.Type(), e)
+	}))
 }
 
 // shutdown is called when the app is closing
 func (a *App) shutdown(ctx context.Context) {
-	a.xmrigService.Stop()
+	a.minerAPI.Shutdown()
 }
 
 // === 挖矿控制相关方法 ===
@@ -56,16 +62,51 @@ func (a *App) GetMinerStatus() (*models.MinerStatus, error) {
 	return a.minerAPI.GetMinerStatus()
 }
 
+// GetPoolSelection 获取最近一次矿池选择结果
+func (a *App) GetPoolSelection() *models.PoolSelection {
+	return a.minerAPI.GetPoolSelection()
+}
+
+// ProbePools 探测所有已启用矿池
+func (a *App) ProbePools() (*models.PoolSelection, error) {
+	return a.minerAPI.ProbePools()
+}
+
+// GetHistory 获取算力历史
+func (a *App) GetHistory(rangeSeconds, resolutionSeconds int64) ([]models.HistorySample, error) {
+	return a.minerAPI.GetHistory(rangeSeconds, resolutionSeconds)
+}
+
 // GetSystemInfo 获取系统信息
 func (a *App) GetSystemInfo() (*models.SystemInfo, error) {
 	return a.minerAPI.GetSystemInfo()
 }
 
+// GetRestartHistory 获取自动重启记录
+func (a *App) GetRestartHistory() []models.RestartRecord {
+	return a.minerAPI.GetRestartHistory()
+}
+
 // GetLogs 获取日志
-func (a *App) GetLogs() []string {
+func (a *App) GetLogs() []models.LogRecord {
 	return a.minerAPI.GetLogs()
 }
 
+// ListLogSessions 列出历次运行的日志文件
+func (a *App) ListLogSessions() ([]models.LogSession, error) {
+	return a.minerAPI.ListLogSessions()
+}
+
+// ReadLogSession 读取一次运行的日志文件
+func (a *App) ReadLogSession(id string, tail int) ([]string, error) {
+	return a.minerAPI.ReadLogSession(id, tail)
+}
+
+// QueryLogs 按条件查询日志，支持翻页与追踪新日志
+func (a *App) QueryLogs(query models.LogQuery) (models.LogPage, error) {
+	return a.minerAPI.QueryLogs(query)
+}
+
 // ClearLogs 清空日志
 func (a *App) ClearLogs() {
 	a.minerAPI.ClearLogs()
@@ -83,6 +124,82 @@ func (a *App) SaveConfig(config *models.XMRigConfig) error {
 	return a.minerAPI.SaveConfig(config)
 }
 
+// ValidateConfig 校验配置
+func (a *App) ValidateConfig(config *models.XMRigConfig) []models.FieldError {
+	return a.minerAPI.ValidateConfig(config)
+}
+
+// GetConfigWarnings 获取配置提醒
+func (a *App) GetConfigWarnings(config *models.XMRigConfig) []models.FieldError {
+	return a.minerAPI.GetConfigWarnings(config)
+}
+
+// ListProfiles 列出配置方案
+func (a *App) ListProfiles() ([]models.ProfileInfo, error) {
+	return a.minerAPI.ListProfiles()
+}
+
+// CreateProfile 新建配置方案
+func (a *App) CreateProfile(name string) error {
+	return a.minerAPI.CreateProfile(name)
+}
+
+// CloneProfile 复制配置方案
+func (a *App) CloneProfile(source, name string) error {
+	return a.minerAPI.CloneProfile(source, name)
+}
+
+// RenameProfile 重命名配置方案
+func (a *App) RenameProfile(oldName, newName string) error {
+	return a.minerAPI.RenameProfile(oldName, newName)
+}
+
+// DeleteProfile 删除配置方案
+func (a *App) DeleteProfile(name string) error {
+	return a.minerAPI.DeleteProfile(name)
+}
+
+// ActivateProfile 切换配置方案，restart 为 true 时挖矿运行中会重启生效
+func (a *App) ActivateProfile(name string, restart bool) error {
+	return a.minerAPI.ActivateProfile(name, restart)
+}
+
+// GetSchedule 获取定时挖矿配置
+func (a *App) GetSchedule() (models.ScheduleConfig, error) {
+	return a.minerAPI.GetSchedule()
+}
+
+// SaveSchedule 保存定时挖矿配置
+func (a *App) SaveSchedule(cfg models.ScheduleConfig) error {
+	return a.minerAPI.SaveSchedule(cfg)
+}
+
+// GetScheduleStatus 获取定时挖矿状态与下一次切换
+func (a *App) GetScheduleStatus() models.ScheduleStatus {
+	return a.minerAPI.GetScheduleStatus()
+}
+
+// GetGovernorStatus 获取负载调节状态
+func (a *App) GetGovernorStatus() models.GovernorStatus {
+	return a.minerAPI.GetGovernorStatus()
+}
+
+// ExportDiagnostics 选择保存位置并导出诊断包，取消时返回空字符串
+func (a *App) ExportDiagnostics() (string, error) {
+	defaultPath := a.minerAPI.DefaultDiagnosticsPath()
+	path, err := wailsruntime.SaveFileDialog(a.ctx, wailsruntime.SaveDialogOptions{
+		Title:           "导出诊断包",
+		DefaultFilename: filepath.Base(defaultPath),
+		Filters: []wailsruntime.FileFilter{
+			{DisplayName: "ZIP 文件 (*.zip)", Pattern: "*.zip"},
+		},
+	})
+	if err != nil || path == "" {
+		return "", err
+	}
+	return a.minerAPI.ExportDiagnostics(path)
+}
+
 // GetDefaultConfig 获取默认配置
 func (a *App) GetDefaultConfig() *models.XMRigConfig {
 	return a.minerAPI.GetDefaultConfig()
@@ -0,0 +1,533 @@
+// Command xdag-miner 是矿工管理器的无界面入口，可作为守护进程运行，
+// 也可作为客户端控制正在运行的守护进程。
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go-wails/internal/api"
+	"go-wails/internal/daemon"
+	"go-wails/internal/events"
+	"go-wails/internal/models"
+	"go-wails/internal/service"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const usage = `用法: xdag-miner [-addr 地址] [-token 令牌] <命令> [参数]
+
+命令:
+  daemon [-start] [-quiet] [-home 目录]
+                             以守护进程方式运行，-start 启动后立即开始挖矿，
+                             -home 指定数据目录（配置、历史等）
+  start                      开始挖矿
+  stop                       停止挖矿
+  status                     查看挖矿状态
+  restarts                   查看自动重启记录
+  pools [-probe]             查看矿池选择结果与延迟，-probe 立即重新探测
+  history [-range 秒] [-resolution 秒]
+                             查看算力历史，默认最近一小时
+  logs [-clear] [-json] [-f] [-n 条数] [-before 序号] [-level 级别] [-tag 标签]
+       [-source 来源] [-since 时长] [-grep 文本] [-regex]
+                             查看运行日志，-clear 清空日志，-json 输出结构化日志，
+                             -f 持续输出新日志，-before 向前翻页，
+                             级别、标签、来源可用逗号分隔多个
+  logs sessions              列出日志文件中保存的历次运行
+  logs show [-tail 行数] <会话>
+                             查看一次运行的日志文件
+  config show                输出当前配置
+  config set <文件>          从 JSON 文件保存配置
+  config default             输出默认配置
+  schedule [status]          查看定时状态与下一次切换
+  schedule show              输出定时规则
+  schedule set <文件>        从 JSON 文件保存定时规则
+  profile list               列出配置方案
+  profile create <名称>      以默认配置新建配置方案
+  profile clone <源> <名称>  复制配置方案
+  profile rename <旧> <新>   重命名配置方案
+  profile delete <名称>      删除配置方案
+  profile use [-restart] <名称>
+                             切换配置方案，挖矿运行中需加 -restart 重启生效
+  governor                   查看负载调节状态
+  sysinfo                    查看系统信息
+  diagnostics [-o 文件]      导出诊断包（脱敏配置、日志、重启记录、系统信息等），
+                             写入守护进程数据目录下的 diagnostics，-o 另复制一份
+
+环境变量:
+  XDAG_MINER_ADDR            控制接口地址，默认 ` + daemon.DefaultAddr + `，只能使用本机地址
+  XDAG_MINER_TOKEN           控制接口令牌，未设置时使用数据目录中的 daemon.token，
+                             守护进程首次启动时自动生成
+  XDAG_MINER_HOME            数据目录，默认为系统配置目录下的 xdag-miner
+`
+
+func main() {
+	flags := flag.NewFlagSet("xdag-miner", flag.ExitOnError)
+	flags.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	addr := flags.String("addr", envOr("XDAG_MINER_ADDR", daemon.DefaultAddr), "控制接口地址")
+	token := flags.String("token", os.Getenv("XDAG_MINER_TOKEN"), "控制接口令牌")
+	flags.Parse(os.Args[1:])
+
+	args := flags.Args()
+	if len(args) == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(*addr, *token, args[0], args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "错误:", err)
+		os.Exit(1)
+	}
+}
+
+func run(addr, token, command string, args []string) error {
+	if command == "daemon" {
+		return runDaemon(addr, token, args)
+	}
+
+	if token == "" {
+		// 与守护进程使用同一数据目录时，直接读取其生成的令牌
+		var err error
+		token, err = daemon.ReadToken(service.NewConfigService().GetDataDir())
+		if err != nil {
+			return err
+		}
+	}
+	client := daemon.NewClient(addr, token)
+	switch command {
+	case "start":
+		return client.Start()
+	case "stop":
+		return client.Stop()
+	case "status":
+		status, err := client.Status()
+		if err != nil {
+			return err
+		}
+		return printJSON(status)
+	case "restarts":
+		history, err := client.RestartHistory()
+		if err != nil {
+			return err
+		}
+		return printJSON(history)
+	case "pools":
+		flags := flag.NewFlagSet("pools", flag.ExitOnError)
+		probe := flags.Bool("probe", false, "立即重新探测")
+		flags.Parse(args)
+		selection, err := client.PoolSelection(*probe)
+		if err != nil {
+			return err
+		}
+		return printJSON(selection)
+	case "history":
+		return runHistory(client, args)
+	case "governor":
+		status, err := client.GovernorStatus()
+		if err != nil {
+			return err
+		}
+		return printJSON(status)
+	case "sysinfo":
+		info, err := client.SystemInfo()
+		if err != nil {
+			return err
+		}
+		return printJSON(info)
+	case "diagnostics":
+		return runDiagnostics(client, args)
+	case "logs":
+		return runLogs(client, args)
+	case "config":
+		return runConfig(client, args)
+	case "profile":
+		return runProfile(client, args)
+	case "schedule":
+		return runSchedule(client, args)
+	default:
+		return fmt.Errorf("未知命令: %s", command)
+	}
+}
+
+// runDaemon 在前台运行服务层与控制接口，收到退出信号后停止挖矿
+func runDaemon(addr, token string, args []string) error {
+	flags := flag.NewFlagSet("daemon", flag.ExitOnError)
+	autoStart := flags.Bool("start", false, "启动后立即开始挖矿")
+	quiet := flags.Bool("quiet", false, "不输出矿工日志")
+	home := flags.String("home", os.Getenv(service.HomeEnv), "数据目录")
+	flags.Parse(args)
+	if err := daemon.CheckListenAddr(addr); err != nil {
+		return err
+	}
+
+	configService := service.NewConfigServiceAt(*home)
+	if token == "" {
+		var err error
+		if token, err = daemon.LoadOrCreateToken(configService.GetDataDir()); err != nil {
+			return err
+		}
+	}
+	xmrigService := service.NewXMRigService(configService)
+	minerAPI := api.NewMinerAPI(xmrigService, configService)
+
+	xmrigService.Events().Subscribe(events.SinkFunc(func(e events.Event) {
+		switch ev := e.(type) {
+		case events.LogLine:
+			if !*quiet {
+				fmt.Println(ev.Line)
+			}
+		case events.Stopped:
+			fmt.Fprintf(os.Stderr, "矿工进程已退出: %s\n", ev.Reason)
+		}
+	}))
+
+	server := daemon.NewServer(minerAPI, addr, token)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+	fmt.Fprintf(os.Stderr, "守护进程已启动，控制地址 %s，数据目录 %s\n", addr, configService.GetDataDir())
+
+	if *autoStart {
+		if err := minerAPI.StartMining(); err != nil {
+			fmt.Fprintln(os.Stderr, "开始挖矿失败:", err)
+		}
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+
+	var runErr error
+	select {
+	case sig := <-sigCh:
+		fmt.Fprintf(os.Stderr, "收到信号 %s，正在退出\n", sig)
+	case runErr = <-errCh:
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = server.Shutdown(ctx)
+	minerAPI.Shutdown()
+	return runErr
+}
+
+func runHistory(client *daemon.Client, args []string) error {
+	flags := flag.NewFlagSet("history", flag.ExitOnError)
+	rangeSeconds := flags.Int64("range", 3600, "查询范围（秒）")
+	resolution := flags.Int64("resolution", 0, "分辨率（秒），0 为自动")
+	flags.Parse(args)
+
+	samples, err := client.History(*rangeSeconds, *resolution)
+	if err != nil {
+		return err
+	}
+	return printJSON(samples)
+}
+
+func runDiagnostics(client *daemon.Client, args []string) error {
+	flags := flag.NewFlagSet("diagnostics", flag.ExitOnError)
+	output := flags.String("o", "", "复制到该文件，默认只保留在数据目录")
+	flags.Parse(args)
+
+	// 守护进程只写入自己的数据目录，需要其他位置时由命令行以当前用户身份复制
+	written, err := client.ExportDiagnostics()
+	if err != nil {
+		return err
+	}
+	if *output == "" {
+		fmt.Println(written)
+		return nil
+	}
+	if err := copyFile(written, *output); err != nil {
+		return err
+	}
+	fmt.Println(*output)
+	return nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("读取诊断包失败: %w", err)
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return fmt.Errorf("创建文件失败: %w", err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return fmt.Errorf("复制诊断包失败: %w", err)
+	}
+	return out.Close()
+}
+
+func runLogs(client *daemon.Client, args []string) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "sessions":
+			return runLogSessions(client)
+		case "show":
+			return runLogShow(client, args[1:])
+		}
+	}
+	flags := flag.NewFlagSet("logs", flag.ExitOnError)
+	clear := flags.Bool("clear", false, "清空日志")
+	asJSON := flags.Bool("json", false, "输出结构化日志")
+	follow := flags.Bool("f", false, "持续输出新日志")
+	limit := flags.Int("n", 0, "最多显示的条数，默认 200")
+	before := flags.Uint64("before", 0, "只显示序号小于该值的日志，用于向前翻页")
+	levels := flags.String("level", "", "日志级别：info、warning、error")
+	tags := flags.String("tag", "", "模块标签，如 net、cpu、miner、manager")
+	sources := flags.String("source", "", "来源：stdout、stderr、manager")
+	since := flags.Duration("since", 0, "只显示最近一段时间的日志，如 10m")
+	text := flags.String("grep", "", "只显示包含该文本的日志")
+	regex := flags.Bool("regex", false, "-grep 按正则表达式匹配")
+	flags.Parse(args)
+
+	if *clear {
+		return client.ClearLogs()
+	}
+	query := models.LogQuery{
+		Levels:  splitList(*levels),
+		Tags:    splitList(*tags),
+		Sources: splitList(*sources),
+		Text:    *text,
+		Regex:   *regex,
+		Before:  *before,
+		Limit:   *limit,
+	}
+	if *since > 0 {
+		query.From = time.Now().Add(-*since).UnixMilli()
+	}
+
+	page, err := client.QueryLogs(query)
+	if err != nil {
+		return err
+	}
+	if !*follow {
+		if *asJSON {
+			return printJSON(page)
+		}
+		printLogRecords(page.Records, false)
+		if page.Cursor > 0 {
+			fmt.Fprintf(os.Stderr, "更早的日志: logs -before %d\n", page.Cursor)
+		}
+		return nil
+	}
+
+	// 追踪模式只获取上次之后的新日志
+	printLogRecords(page.Records, *asJSON)
+	query.Before = 0
+	for {
+		query.After = page.Latest
+		if !page.More {
+			time.Sleep(time.Second)
+		}
+		if page, err = client.QueryLogs(query); err != nil {
+			return err
+		}
+		if page.Truncated {
+			fmt.Fprintln(os.Stderr, "部分日志已超出缓冲区未能显示，可通过 logs show 查看日志文件")
+		}
+		printLogRecords(page.Records, *asJSON)
+	}
+}
+
+// printLogRecords 输出日志，asJSON 时每行一条 JSON
+func printLogRecords(records []models.LogRecord, asJSON bool) {
+	for _, record := range records {
+		if asJSON {
+			data, _ := json.Marshal(record)
+			fmt.Println(string(data))
+			continue
+		}
+		fmt.Println(record.Line)
+	}
+}
+
+// splitList 拆分逗号分隔的参数
+func splitList(v string) []string {
+	var list []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func runLogSessions(client *daemon.Client) error {
+	sessions, err := client.LogSessions()
+	if err != nil {
+		return err
+	}
+	for _, s := range sessions {
+		mark := " "
+		if s.Active {
+			mark = "*"
+		}
+		fmt.Printf("%s %s  %s - %s  %d 个文件  %d 字节\n", mark, s.ID,
+			time.Unix(s.Start, 0).Format("2006-01-02 15:04:05"),
+			time.Unix(s.Modified, 0).Format("2006-01-02 15:04:05"), s.Files, s.Size)
+	}
+	return nil
+}
+
+func runLogShow(client *daemon.Client, args []string) error {
+	flags := flag.NewFlagSet("logs show", flag.ExitOnError)
+	tail := flags.Int("tail", 0, "只显示最后若干行，0 为全部")
+	flags.Parse(args)
+	if flags.NArg() == 0 {
+		return fmt.Errorf("请指定日志会话，可通过 logs sessions 查看")
+	}
+	lines, err := client.ReadLogSession(flags.Arg(0), *tail)
+	if err != nil {
+		return err
+	}
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+	return nil
+}
+
+func runConfig(client *daemon.Client, args []string) error {
+	if len(args) == 0 {
+		args = []string{"show"}
+	}
+	switch args[0] {
+	case "show":
+		cfg, err := client.LoadConfig()
+		if err != nil {
+			return err
+		}
+		return printJSON(cfg)
+	case "default":
+		return printJSON(service.NewConfigService().GetDefaultConfig())
+	case "set":
+		if len(args) < 2 {
+			return fmt.Errorf("请指定配置文件")
+		}
+		data, err := os.ReadFile(args[1])
+		if err != nil {
+			return fmt.Errorf("读取配置文件失败: %w", err)
+		}
+		var cfg models.XMRigConfig
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return fmt.Errorf("解析配置文件失败: %w", err)
+		}
+		return client.SaveConfig(&cfg)
+	default:
+		return fmt.Errorf("未知的 config 子命令: %s", args[0])
+	}
+}
+
+func runSchedule(client *daemon.Client, args []string) error {
+	if len(args) == 0 {
+		args = []string{"status"}
+	}
+	switch args[0] {
+	case "status":
+		status, err := client.ScheduleStatus()
+		if err != nil {
+			return err
+		}
+		return printJSON(status)
+	case "show":
+		cfg, err := client.Schedule()
+		if err != nil {
+			return err
+		}
+		return printJSON(cfg)
+	case "set":
+		if len(args) < 2 {
+			return fmt.Errorf("请指定定时规则文件")
+		}
+		data, err := os.ReadFile(args[1])
+		if err != nil {
+			return fmt.Errorf("读取定时规则文件失败: %w", err)
+		}
+		var cfg models.ScheduleConfig
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return fmt.Errorf("解析定时规则文件失败: %w", err)
+		}
+		return client.SaveSchedule(&cfg)
+	default:
+		return fmt.Errorf("未知的 schedule 子命令: %s", args[0])
+	}
+}
+
+func runProfile(client *daemon.Client, args []string) error {
+	if len(args) == 0 {
+		args = []string{"list"}
+	}
+	need := func(n int) error {
+		if len(args)-1 < n {
+			return fmt.Errorf("profile %s 需要 %d 个参数", args[0], n)
+		}
+		return nil
+	}
+	switch args[0] {
+	case "list":
+		profiles, err := client.Profiles()
+		if err != nil {
+			return err
+		}
+		for _, p := range profiles {
+			mark := " "
+			if p.Active {
+				mark = "*"
+			}
+			fmt.Printf("%s %s (%d 个矿池)\n", mark, p.Name, p.Pools)
+		}
+		return nil
+	case "create":
+		if err := need(1); err != nil {
+			return err
+		}
+		return client.CreateProfile(args[1])
+	case "clone":
+		if err := need(2); err != nil {
+			return err
+		}
+		return client.CloneProfile(args[1], args[2])
+	case "rename":
+		if err := need(2); err != nil {
+			return err
+		}
+		return client.RenameProfile(args[1], args[2])
+	case "delete":
+		if err := need(1); err != nil {
+			return err
+		}
+		return client.DeleteProfile(args[1])
+	case "use":
+		flags := flag.NewFlagSet("profile use", flag.ExitOnError)
+		restart := flags.Bool("restart", false, "挖矿运行中时重启生效")
+		flags.Parse(args[1:])
+		if flags.NArg() != 1 {
+			return fmt.Errorf("请指定配置方案名称")
+		}
+		return client.ActivateProfile(flags.Arg(0), *restart)
+	default:
+		return fmt.Errorf("未知的 profile 子命令: %s", args[0])
+	}
+}
+
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
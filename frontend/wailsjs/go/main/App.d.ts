@@ -2,20 +2,60 @@
 // This file is automatically generated. DO NOT EDIT
 import {models} from '../models';
 
+export function ActivateProfile(arg1:string,arg2:boolean):Promise<void>;
+
 export function ClearLogs():Promise<void>;
 
+export function CloneProfile(arg1:string,arg2:string):Promise<void>;
+
+export function CreateProfile(arg1:string):Promise<void>;
+
+export function DeleteProfile(arg1:string):Promise<void>;
+
+export function ExportDiagnostics():Promise<string>;
+
+export function GetConfigWarnings(arg1:models.XMRigConfig):Promise<Array<models.FieldError>>;
+
 export function GetDefaultConfig():Promise<models.XMRigConfig>;
 
-export function GetLogs():Promise<Array<string>>;
+export function GetGovernorStatus():Promise<models.GovernorStatus>;
+
+export function GetHistory(arg1:number,arg2:number):Promise<Array<models.HistorySample>>;
+
+export function GetLogs():Promise<Array<models.LogRecord>>;
 
 export function GetMinerStatus():Promise<models.MinerStatus>;
 
+export function GetPoolSelection():Promise<models.PoolSelection>;
+
+export function GetRestartHistory():Promise<Array<models.RestartRecord>>;
+
+export function GetSchedule():Promise<models.ScheduleConfig>;
+
+export function GetScheduleStatus():Promise<models.ScheduleStatus>;
+
 export function GetSystemInfo():Promise<models.SystemInfo>;
 
+export function ListLogSessions():Promise<Array<models.LogSession>>;
+
+export function ListProfiles():Promise<Array<models.ProfileInfo>>;
+
 export function LoadConfig():Promise<models.XMRigConfig>;
 
+export function ProbePools():Promise<models.PoolSelection>;
+
+export function QueryLogs(arg1:models.LogQuery):Promise<models.LogPage>;
+
+export function ReadLogSession(arg1:string,arg2:number):Promise<Array<string>>;
+
+export function RenameProfile(arg1:string,arg2:string):Promise<void>;
+
 export function SaveConfig(arg1:models.XMRigConfig):Promise<void>;
 
+export function SaveSchedule(arg1:models.ScheduleConfig):Promise<void>;
+
 export function StartMining():Promise<void>;
 
 export function StopMining():Promise<void>;
+
+export function ValidateConfig(arg1:models.XMRigConfig):Promise<Array<models.FieldError>>;
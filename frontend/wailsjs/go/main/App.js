@@ -2,14 +2,46 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function ActivateProfile(arg1, arg2) {
+  return window['go']['main']['App']['ActivateProfile'](arg1, arg2);
+}
+
 export function ClearLogs() {
   return window['go']['main']['App']['ClearLogs']();
 }
 
+export function CloneProfile(arg1, arg2) {
+  return window['go']['main']['App']['CloneProfile'](arg1, arg2);
+}
+
+export function CreateProfile(arg1) {
+  return window['go']['main']['App']['CreateProfile'](arg1);
+}
+
+export function DeleteProfile(arg1) {
+  return window['go']['main']['App']['DeleteProfile'](arg1);
+}
+
+export function ExportDiagnostics() {
+  return window['go']['main']['App']['ExportDiagnostics']();
+}
+
+export function GetConfigWarnings(arg1) {
+  return window['go']['main']['App']['GetConfigWarnings'](arg1);
+}
+
 export function GetDefaultConfig() {
   return window['go']['main']['App']['GetDefaultConfig']();
 }
 
+export function GetGovernorStatus() {
+  return window['go']['main']['App']['GetGovernorStatus']();
+}
+
+export function GetHistory(arg1, arg2) {
+  return window['go']['main']['App']['GetHistory'](arg1, arg2);
+}
+
 export function GetLogs() {
   return window['go']['main']['App']['GetLogs']();
 }
@@ -18,18 +50,62 @@ export function GetMinerStatus() {
   return window['go']['main']['App']['GetMinerStatus']();
 }
 
+export function GetPoolSelection() {
+  return window['go']['main']['App']['GetPoolSelection']();
+}
+
+export function GetRestartHistory() {
+  return window['go']['main']['App']['GetRestartHistory']();
+}
+
+export function GetSchedule() {
+  return window['go']['main']['App']['GetSchedule']();
+}
+
+export function GetScheduleStatus() {
+  return window['go']['main']['App']['GetScheduleStatus']();
+}
+
 export function GetSystemInfo() {
   return window['go']['main']['App']['GetSystemInfo']();
 }
 
+export function ListLogSessions() {
+  return window['go']['main']['App']['ListLogSessions']();
+}
+
+export function ListProfiles() {
+  return window['go']['main']['App']['ListProfiles']();
+}
+
 export function LoadConfig() {
   return window['go']['main']['App']['LoadConfig']();
 }
 
+export function ProbePools() {
+  return window['go']['main']['App']['ProbePools']();
+}
+
+export function QueryLogs(arg1) {
+  return window['go']['main']['App']['QueryLogs'](arg1);
+}
+
+export function ReadLogSession(arg1, arg2) {
+  return window['go']['main']['App']['ReadLogSession'](arg1, arg2);
+}
+
+export function RenameProfile(arg1, arg2) {
+  return window['go']['main']['App']['RenameProfile'](arg1, arg2);
+}
+
 export function SaveConfig(arg1) {
   return window['go']['main']['App']['SaveConfig'](arg1);
 }
 
+export function SaveSchedule(arg1) {
+  return window['go']['main']['App']['SaveSchedule'](arg1);
+}
+
 export function StartMining() {
   return window['go']['main']['App']['StartMining']();
 }
@@ -37,3 +113,7 @@ export function StartMining() {
 export function StopMining() {
   return window['go']['main']['App']['StopMining']();
 }
+
+export function ValidateConfig(arg1) {
+  return window['go']['main']['App']['ValidateConfig'](arg1);
+}
@@ -14,6 +14,42 @@ export namespace models {
 	        this["worker-id"] = source["worker-id"];
 	    }
 	}
+	export class BinaryConfig {
+	    provider: string;
+	    path: string;
+	    dir: string;
+	    sha256: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BinaryConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.provider = source["provider"];
+	        this.path = source["path"];
+	        this.dir = source["dir"];
+	        this.sha256 = source["sha256"];
+	    }
+	}
+	export class CPUCache {
+	    level: number;
+	    type: string;
+	    size: number;
+	    count: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CPUCache(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.level = source["level"];
+	        this.type = source["type"];
+	        this.size = source["size"];
+	        this.count = source["count"];
+	    }
+	}
 	export class CPUConfig {
 	    enabled: boolean;
 	    "huge-pages": boolean;
@@ -34,6 +70,90 @@ export namespace models {
 	        this.asm = source["asm"];
 	    }
 	}
+	export class FailoverConfig {
+	    disabled: boolean;
+	    "check-interval-seconds": number;
+	    "disconnected-seconds": number;
+	    "max-reject-ratio": number;
+	    "min-shares": number;
+	    method: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new FailoverConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.disabled = source["disabled"];
+	        this["check-interval-seconds"] = source["check-interval-seconds"];
+	        this["disconnected-seconds"] = source["disconnected-seconds"];
+	        this["max-reject-ratio"] = source["max-reject-ratio"];
+	        this["min-shares"] = source["min-shares"];
+	        this.method = source["method"];
+	    }
+	}
+	export class FieldError {
+	    field: string;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new FieldError(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.field = source["field"];
+	        this.message = source["message"];
+	    }
+	}
+	export class GovernorConfig {
+	    enabled: boolean;
+	    mode: string;
+	    "high-load": number;
+	    "low-load": number;
+	    "sample-seconds": number;
+	    "high-samples": number;
+	    "low-samples": number;
+	    "reduced-threads-hint": number;
+	
+	    static createFrom(source: any = {}) {
+	        return new GovernorConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.mode = source["mode"];
+	        this["high-load"] = source["high-load"];
+	        this["low-load"] = source["low-load"];
+	        this["sample-seconds"] = source["sample-seconds"];
+	        this["high-samples"] = source["high-samples"];
+	        this["low-samples"] = source["low-samples"];
+	        this["reduced-threads-hint"] = source["reduced-threads-hint"];
+	    }
+	}
+	export class GovernorStatus {
+	    enabled: boolean;
+	    supported: boolean;
+	    state: string;
+	    mode: string;
+	    load: number;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GovernorStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.supported = source["supported"];
+	        this.state = source["state"];
+	        this.mode = source["mode"];
+	        this.load = source["load"];
+	        this.error = source["error"];
+	    }
+	}
 	export class HTTPConfig {
 	    enabled: boolean;
 	    host: string;
@@ -54,13 +174,367 @@ export namespace models {
 	        this.restricted = source["restricted"];
 	    }
 	}
+	export class HistoryConfig {
+	    disabled: boolean;
+	    "interval-seconds": number;
+	    "retention-days": number;
+	
+	    static createFrom(source: any = {}) {
+	        return new HistoryConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.disabled = source["disabled"];
+	        this["interval-seconds"] = source["interval-seconds"];
+	        this["retention-days"] = source["retention-days"];
+	    }
+	}
+	export class HistorySample {
+	    time: number;
+	    running: boolean;
+	    hashrate: number;
+	    hashrate60s: number;
+	    sharesAccepted: number;
+	    sharesRejected: number;
+	    threads: number;
+	    pool: string;
+	    connected: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new HistorySample(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.time = source["time"];
+	        this.running = source["running"];
+	        this.hashrate = source["hashrate"];
+	        this.hashrate60s = source["hashrate60s"];
+	        this.sharesAccepted = source["sharesAccepted"];
+	        this.sharesRejected = source["sharesRejected"];
+	        this.threads = source["threads"];
+	        this.pool = source["pool"];
+	        this.connected = source["connected"];
+	    }
+	}
+	export class HugePagesInfo {
+	    supported: boolean;
+	    available: boolean;
+	    pageSize: number;
+	    total: number;
+	    free: number;
+	    oneGB: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new HugePagesInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.supported = source["supported"];
+	        this.available = source["available"];
+	        this.pageSize = source["pageSize"];
+	        this.total = source["total"];
+	        this.free = source["free"];
+	        this.oneGB = source["oneGB"];
+	    }
+	}
+	export class JobLog {
+	    pool: string;
+	    diff: number;
+	    algo: string;
+	    height: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new JobLog(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.pool = source["pool"];
+	        this.diff = source["diff"];
+	        this.algo = source["algo"];
+	        this.height = source["height"];
+	    }
+	}
+	export class SpeedLog {
+	    hashrate10s: number;
+	    hashrate60s: number;
+	    hashrate15m: number;
+	    highest: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SpeedLog(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hashrate10s = source["hashrate10s"];
+	        this.hashrate60s = source["hashrate60s"];
+	        this.hashrate15m = source["hashrate15m"];
+	        this.highest = source["highest"];
+	    }
+	}
+	export class ShareLog {
+	    accepted: boolean;
+	    total: number;
+	    rejected: number;
+	    diff: number;
+	    latencyMs: number;
+	    reason?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ShareLog(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.accepted = source["accepted"];
+	        this.total = source["total"];
+	        this.rejected = source["rejected"];
+	        this.diff = source["diff"];
+	        this.latencyMs = source["latencyMs"];
+	        this.reason = source["reason"];
+	    }
+	}
+	export class LogRecord {
+	    seq: number;
+	    time: number;
+	    source: string;
+	    tag: string;
+	    level: string;
+	    message: string;
+	    line: string;
+	    share?: ShareLog;
+	    job?: JobLog;
+	    speed?: SpeedLog;
+	
+	    static createFrom(source: any = {}) {
+	        return new LogRecord(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.seq = source["seq"];
+	        this.time = source["time"];
+	        this.source = source["source"];
+	        this.tag = source["tag"];
+	        this.level = source["level"];
+	        this.message = source["message"];
+	        this.line = source["line"];
+	        this.share = this.convertValues(source["share"], ShareLog);
+	        this.job = this.convertValues(source["job"], JobLog);
+	        this.speed = this.convertValues(source["speed"], SpeedLog);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class LogPage {
+	    records: LogRecord[];
+	    cursor: number;
+	    latest: number;
+	    more: boolean;
+	    truncated: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new LogPage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.records = this.convertValues(source["records"], LogRecord);
+	        this.cursor = source["cursor"];
+	        this.latest = source["latest"];
+	        this.more = source["more"];
+	        this.truncated = source["truncated"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class LogQuery {
+	    levels?: string[];
+	    tags?: string[];
+	    sources?: string[];
+	    from?: number;
+	    to?: number;
+	    text?: string;
+	    regex?: boolean;
+	    before?: number;
+	    after?: number;
+	    limit?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new LogQuery(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.levels = source["levels"];
+	        this.tags = source["tags"];
+	        this.sources = source["sources"];
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.text = source["text"];
+	        this.regex = source["regex"];
+	        this.before = source["before"];
+	        this.after = source["after"];
+	        this.limit = source["limit"];
+	    }
+	}
+	
+	export class LogSession {
+	    id: string;
+	    start: number;
+	    modified: number;
+	    size: number;
+	    files: number;
+	    active: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new LogSession(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.start = source["start"];
+	        this.modified = source["modified"];
+	        this.size = source["size"];
+	        this.files = source["files"];
+	        this.active = source["active"];
+	    }
+	}
+	export class LogsConfig {
+	    disabled: boolean;
+	    "max-size-mb": number;
+	    "max-age-hours": number;
+	    "retention-days": number;
+	    "max-files": number;
+	
+	    static createFrom(source: any = {}) {
+	        return new LogsConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.disabled = source["disabled"];
+	        this["max-size-mb"] = source["max-size-mb"];
+	        this["max-age-hours"] = source["max-age-hours"];
+	        this["retention-days"] = source["retention-days"];
+	        this["max-files"] = source["max-files"];
+	    }
+	}
+	export class SupervisorConfig {
+	    disabled: boolean;
+	    "max-restarts": number;
+	    "window-seconds": number;
+	    "initial-backoff": number;
+	    "max-backoff": number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SupervisorConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.disabled = source["disabled"];
+	        this["max-restarts"] = source["max-restarts"];
+	        this["window-seconds"] = source["window-seconds"];
+	        this["initial-backoff"] = source["initial-backoff"];
+	        this["max-backoff"] = source["max-backoff"];
+	    }
+	}
+	export class ManagerConfig {
+	    "pool-strategy": string;
+	    binary: BinaryConfig;
+	    supervisor: SupervisorConfig;
+	    history: HistoryConfig;
+	    failover: FailoverConfig;
+	    governor: GovernorConfig;
+	    logs: LogsConfig;
+	
+	    static createFrom(source: any = {}) {
+	        return new ManagerConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this["pool-strategy"] = source["pool-strategy"];
+	        this.binary = this.convertValues(source["binary"], BinaryConfig);
+	        this.supervisor = this.convertValues(source["supervisor"], SupervisorConfig);
+	        this.history = this.convertValues(source["history"], HistoryConfig);
+	        this.failover = this.convertValues(source["failover"], FailoverConfig);
+	        this.governor = this.convertValues(source["governor"], GovernorConfig);
+	        this.logs = this.convertValues(source["logs"], LogsConfig);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class MinerStatus {
 	    running: boolean;
 	    hashrate: number;
+	    hashrate10s: number;
+	    hashrate60s: number;
+	    hashrate15m: number;
+	    hashrateHighest: number;
 	    threads: number;
 	    uptime: number;
 	    pool: string;
+	    poolPing: number;
 	    algorithm: string;
+	    difficulty: number;
+	    sharesAccepted: number;
+	    sharesRejected: number;
+	    sharesInvalid: number;
 	    connected: boolean;
 	
 	    static createFrom(source: any = {}) {
@@ -71,10 +545,19 @@ export namespace models {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.running = source["running"];
 	        this.hashrate = source["hashrate"];
+	        this.hashrate10s = source["hashrate10s"];
+	        this.hashrate60s = source["hashrate60s"];
+	        this.hashrate15m = source["hashrate15m"];
+	        this.hashrateHighest = source["hashrateHighest"];
 	        this.threads = source["threads"];
 	        this.uptime = source["uptime"];
 	        this.pool = source["pool"];
+	        this.poolPing = source["poolPing"];
 	        this.algorithm = source["algorithm"];
+	        this.difficulty = source["difficulty"];
+	        this.sharesAccepted = source["sharesAccepted"];
+	        this.sharesRejected = source["sharesRejected"];
+	        this.sharesInvalid = source["sharesInvalid"];
 	        this.connected = source["connected"];
 	    }
 	}
@@ -88,6 +571,7 @@ export namespace models {
 	    nicehash: boolean;
 	    enabled: boolean;
 	    tls: boolean;
+	    weight?: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new PoolConfig(source);
@@ -104,6 +588,81 @@ export namespace models {
 	        this.nicehash = source["nicehash"];
 	        this.enabled = source["enabled"];
 	        this.tls = source["tls"];
+	        this.weight = source["weight"];
+	    }
+	}
+	export class PoolProbe {
+	    url: string;
+	    ok: boolean;
+	    class: string;
+	    error?: string;
+	    connectMs: number;
+	    latencyMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PoolProbe(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.url = source["url"];
+	        this.ok = source["ok"];
+	        this.class = source["class"];
+	        this.error = source["error"];
+	        this.connectMs = source["connectMs"];
+	        this.latencyMs = source["latencyMs"];
+	    }
+	}
+	export class PoolSelection {
+	    strategy: string;
+	    chosen: string;
+	    time: number;
+	    probes: PoolProbe[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PoolSelection(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.strategy = source["strategy"];
+	        this.chosen = source["chosen"];
+	        this.time = source["time"];
+	        this.probes = this.convertValues(source["probes"], PoolProbe);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ProfileInfo {
+	    name: string;
+	    active: boolean;
+	    pools: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProfileInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.active = source["active"];
+	        this.pools = source["pools"];
 	    }
 	}
 	export class RandomXConfig {
@@ -126,13 +685,160 @@ export namespace models {
 	        this.numa = source["numa"];
 	    }
 	}
+	export class RestartRecord {
+	    time: number;
+	    exitCode: number;
+	    reason: string;
+	    attempt: number;
+	    delay: number;
+	    action: string;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RestartRecord(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.time = source["time"];
+	        this.exitCode = source["exitCode"];
+	        this.reason = source["reason"];
+	        this.attempt = source["attempt"];
+	        this.delay = source["delay"];
+	        this.action = source["action"];
+	        this.error = source["error"];
+	    }
+	}
+	export class ScheduleRule {
+	    name: string;
+	    days: string[];
+	    start: string;
+	    end: string;
+	    action: string;
+	    profile?: string;
+	    "max-threads-hint"?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScheduleRule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.days = source["days"];
+	        this.start = source["start"];
+	        this.end = source["end"];
+	        this.action = source["action"];
+	        this.profile = source["profile"];
+	        this["max-threads-hint"] = source["max-threads-hint"];
+	    }
+	}
+	export class ScheduleConfig {
+	    enabled: boolean;
+	    timezone: string;
+	    "default-action": string;
+	    rules: ScheduleRule[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ScheduleConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.timezone = source["timezone"];
+	        this["default-action"] = source["default-action"];
+	        this.rules = this.convertValues(source["rules"], ScheduleRule);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class ScheduleStatus {
+	    enabled: boolean;
+	    rule: string;
+	    action: string;
+	    profile: string;
+	    maxThreadsHint: number;
+	    // Go type: time
+	    next?: any;
+	    nextRule: string;
+	    nextAction: string;
+	    nextProfile: string;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScheduleStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.rule = source["rule"];
+	        this.action = source["action"];
+	        this.profile = source["profile"];
+	        this.maxThreadsHint = source["maxThreadsHint"];
+	        this.next = this.convertValues(source["next"], null);
+	        this.nextRule = source["nextRule"];
+	        this.nextAction = source["nextAction"];
+	        this.nextProfile = source["nextProfile"];
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	
 	export class SystemInfo {
 	    os: string;
 	    arch: string;
 	    cpuModel: string;
 	    cpuCores: number;
+	    cpuPhysicalCores: number;
+	    cpuCaches: CPUCache[];
+	    numaNodes: number;
+	    aes: boolean;
+	    avx2: boolean;
 	    totalMemory: number;
+	    freeMemory: number;
+	    hugePages: HugePagesInfo;
 	    xmrigVersion: string;
+	    xmrigPath: string;
+	    xmrigSource: string;
+	    error?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new SystemInfo(source);
@@ -144,9 +850,37 @@ export namespace models {
 	        this.arch = source["arch"];
 	        this.cpuModel = source["cpuModel"];
 	        this.cpuCores = source["cpuCores"];
+	        this.cpuPhysicalCores = source["cpuPhysicalCores"];
+	        this.cpuCaches = this.convertValues(source["cpuCaches"], CPUCache);
+	        this.numaNodes = source["numaNodes"];
+	        this.aes = source["aes"];
+	        this.avx2 = source["avx2"];
 	        this.totalMemory = source["totalMemory"];
+	        this.freeMemory = source["freeMemory"];
+	        this.hugePages = this.convertValues(source["hugePages"], HugePagesInfo);
 	        this.xmrigVersion = source["xmrigVersion"];
+	        this.xmrigPath = source["xmrigPath"];
+	        this.xmrigSource = source["xmrigSource"];
+	        this.error = source["error"];
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
 	export class XMRigConfig {
 	    api: APIConfig;
@@ -156,6 +890,9 @@ export namespace models {
 	    pools: PoolConfig[];
 	    randomx: RandomXConfig;
 	    "log-file"?: string;
+	    "pause-on-battery": boolean;
+	    "pause-on-active": number;
+	    manager: ManagerConfig;
 	
 	    static createFrom(source: any = {}) {
 	        return new XMRigConfig(source);
@@ -170,6 +907,9 @@ export namespace models {
 	        this.pools = this.convertValues(source["pools"], PoolConfig);
 	        this.randomx = this.convertValues(source["randomx"], RandomXConfig);
 	        this["log-file"] = source["log-file"];
+	        this["pause-on-battery"] = source["pause-on-battery"];
+	        this["pause-on-active"] = source["pause-on-active"];
+	        this.manager = this.convertValues(source["manager"], ManagerConfig);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
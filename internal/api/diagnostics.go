@@ -0,0 +1,130 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-wails/internal/diagnostics"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// 诊断包收集的范围
+const (
+	diagnosticsHistory     = 6 * time.Hour
+	diagnosticsLogSessions = 3
+	diagnosticsLogTail     = 5000
+)
+
+// DefaultDiagnosticsPath 返回数据目录下带时间戳的诊断包路径
+func (api *MinerAPI) DefaultDiagnosticsPath() string {
+	name := fmt.Sprintf("xdag-miner-diagnostics-%s.zip", time.Now().Format("20060102-150405"))
+	return filepath.Join(api.configService.GetDataDir(), "diagnostics", name)
+}
+
+// ExportDiagnostics 将脱敏后的配置、近期日志、重启记录、系统信息、
+// XMRig 版本与最近的状态写入 zip，path 为空时写入数据目录，返回实际路径
+func (api *MinerAPI) ExportDiagnostics(path string) (string, error) {
+	if path == "" {
+		path = api.DefaultDiagnosticsPath()
+	}
+	bundle, err := diagnostics.Create(path)
+	if err != nil {
+		return "", err
+	}
+
+	// 配置最先写入，其中的钱包与令牌会在之后的日志中一并隐藏
+	if settings, err := api.configService.LoadSettings(); err != nil {
+		bundle.AddError("settings.json", err)
+	} else {
+		bundle.AddConfig("settings.json", settings)
+	}
+	if data, err := os.ReadFile(api.configService.GetRuntimeConfigPath()); err == nil {
+		var runtimeConfig interface{}
+		if err := json.Unmarshal(data, &runtimeConfig); err != nil {
+			bundle.AddError("xmrig-config.json", err)
+		} else {
+			bundle.AddConfig("xmrig-config.json", runtimeConfig)
+		}
+	} else if !os.IsNotExist(err) {
+		bundle.AddError("xmrig-config.json", err)
+	}
+
+	if info, err := api.GetSystemInfo(); err != nil {
+		bundle.AddError("system.json", err)
+	} else {
+		bundle.AddJSON("system.json", info)
+	}
+	if version, err := api.xmrigService.XMRigVersionOutput(); err != nil {
+		bundle.AddError("xmrig-version.txt", err)
+		if version != "" {
+			bundle.AddText("xmrig-version.txt", version)
+		}
+	} else {
+		bundle.AddText("xmrig-version.txt", version)
+	}
+
+	api.addStatus(bundle)
+	bundle.AddJSON("restarts.json", api.GetRestartHistory())
+	if samples, err := api.sampler.Query(diagnosticsHistory, 0); err != nil {
+		bundle.AddError("history.json", err)
+	} else {
+		bundle.AddJSON("history.json", samples)
+	}
+	api.addLogs(bundle)
+
+	if err := bundle.Close(); err != nil {
+		_ = os.Remove(path)
+		return "", err
+	}
+	return path, nil
+}
+
+// addStatus 写入当前挖矿、矿池选择、定时与负载调节状态
+func (api *MinerAPI) addStatus(bundle *diagnostics.Bundle) {
+	status := map[string]interface{}{
+		"time":     time.Now().Format(time.RFC3339),
+		"pools":    api.GetPoolSelection(),
+		"schedule": api.GetScheduleStatus(),
+		"governor": api.GetGovernorStatus(),
+	}
+	if miner, err := api.GetMinerStatus(); err != nil {
+		bundle.AddError("status.json", err)
+	} else {
+		status["miner"] = miner
+	}
+	bundle.AddJSON("status.json", status)
+}
+
+// addLogs 写入内存中的结构化日志与最近几次运行的日志文件
+func (api *MinerAPI) addLogs(bundle *diagnostics.Bundle) {
+	var recent strings.Builder
+	for _, record := range api.GetLogs() {
+		data, err := json.Marshal(record)
+		if err != nil {
+			continue
+		}
+		recent.Write(data)
+		recent.WriteByte('\n')
+	}
+	bundle.AddText("logs/recent.jsonl", recent.String())
+
+	sessions, err := api.ListLogSessions()
+	if err != nil {
+		bundle.AddError("logs", err)
+		return
+	}
+	if len(sessions) > diagnosticsLogSessions {
+		sessions = sessions[:diagnosticsLogSessions]
+	}
+	for _, session := range sessions {
+		name := "logs/" + session.ID + ".log"
+		lines, err := api.ReadLogSession(session.ID, diagnosticsLogTail)
+		if err != nil {
+			bundle.AddError(name, err)
+			continue
+		}
+		bundle.AddText(name, strings.Join(lines, "\n")+"\n")
+	}
+}
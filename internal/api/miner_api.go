@@ -4,30 +4,77 @@ import (
 	"fmt"
 	"go-wails/internal/models"
 	"go-wails/internal/service"
+	"time"
 )
 
 // MinerAPI 挖矿API
 type MinerAPI struct {
 	xmrigService  *service.XMRigService
 	configService *service.ConfigService
+	supervisor    *service.Supervisor
+	sampler       *service.HistorySampler
+	poolMonitor   *service.PoolMonitor
+	scheduler     *service.Scheduler
+	governor      *service.Governor
 }
 
 // NewMinerAPI 创建挖矿API
 func NewMinerAPI(xmrigService *service.XMRigService, configService *service.ConfigService) *MinerAPI {
-	return &MinerAPI{
+	supervisor := service.NewSupervisor(xmrigService, configService)
+	api := &MinerAPI{
 		xmrigService:  xmrigService,
 		configService: configService,
+		supervisor:    supervisor,
+		sampler:       service.NewHistorySampler(xmrigService, configService),
+		poolMonitor:   service.NewPoolMonitor(supervisor, configService),
+		scheduler:     service.NewScheduler(supervisor, configService, nil),
+		governor:      service.NewGovernor(xmrigService, configService),
 	}
+	api.checkConfig()
+	api.sampler.Start()
+	api.poolMonitor.Start()
+	api.scheduler.Start()
+	api.governor.Start()
+	return api
+}
+
+// checkConfig 校验已保存的配置，手动修改配置文件引入的问题在启动时就提示，而不是等到开始挖矿才失败
+func (api *MinerAPI) checkConfig() {
+	cfg, err := api.configService.LoadConfig()
+	if err != nil {
+		api.xmrigService.Notify(models.LogError, fmt.Sprintf("加载配置失败: %v", err))
+		return
+	}
+	if errs := service.ValidateConfig(cfg); len(errs) > 0 {
+		err := &service.ConfigValidationError{Errors: errs}
+		api.xmrigService.Notify(models.LogWarning, fmt.Sprintf("%v，请在开始挖矿前修改", err))
+	}
+}
+
+// Shutdown 停止挖矿并释放后台任务
+func (api *MinerAPI) Shutdown() {
+	api.governor.Stop()
+	api.scheduler.Stop()
+	api.poolMonitor.Stop()
+	api.sampler.Stop()
+	api.supervisor.Close()
+	_ = api.xmrigService.Stop()
+	_ = api.xmrigService.Close()
 }
 
 // StartMining 开始挖矿
 func (api *MinerAPI) StartMining() error {
-	return api.xmrigService.Start()
+	return api.supervisor.Start()
 }
 
 // StopMining 停止挖矿
 func (api *MinerAPI) StopMining() error {
-	return api.xmrigService.Stop()
+	return api.supervisor.Stop()
+}
+
+// GetRestartHistory 获取自动重启记录
+func (api *MinerAPI) GetRestartHistory() []models.RestartRecord {
+	return api.supervisor.History()
 }
 
 // GetMinerStatus 获取挖矿状态
@@ -35,16 +82,46 @@ func (api *MinerAPI) GetMinerStatus() (*models.MinerStatus, error) {
 	return api.xmrigService.GetStatus()
 }
 
+// GetPoolSelection 获取最近一次矿池选择的策略与各矿池延迟
+func (api *MinerAPI) GetPoolSelection() *models.PoolSelection {
+	return api.xmrigService.LastPoolSelection()
+}
+
+// ProbePools 立即探测所有已启用矿池
+func (api *MinerAPI) ProbePools() (*models.PoolSelection, error) {
+	return api.xmrigService.ProbePools()
+}
+
+// GetHistory 获取最近 rangeSeconds 秒的算力历史，resolutionSeconds 为 0 时自动降采样
+func (api *MinerAPI) GetHistory(rangeSeconds, resolutionSeconds int64) ([]models.HistorySample, error) {
+	return api.sampler.Query(time.Duration(rangeSeconds)*time.Second, time.Duration(resolutionSeconds)*time.Second)
+}
+
 // GetSystemInfo 获取系统信息
 func (api *MinerAPI) GetSystemInfo() (*models.SystemInfo, error) {
 	return api.xmrigService.GetSystemInfo()
 }
 
 // GetLogs 获取日志
-func (api *MinerAPI) GetLogs() []string {
+func (api *MinerAPI) GetLogs() []models.LogRecord {
 	return api.xmrigService.GetLogs()
 }
 
+// QueryLogs 按级别、标签、来源、时间与文本查询日志，支持翻页与追踪新日志
+func (api *MinerAPI) QueryLogs(query models.LogQuery) (models.LogPage, error) {
+	return api.xmrigService.QueryLogs(query)
+}
+
+// ListLogSessions 列出日志文件中保存的历次运行，最新的在前
+func (api *MinerAPI) ListLogSessions() ([]models.LogSession, error) {
+	return api.xmrigService.LogSessions()
+}
+
+// ReadLogSession 读取一次运行的日志文件，tail 大于 0 时只返回最后 tail 行
+func (api *MinerAPI) ReadLogSession(id string, tail int) ([]string, error) {
+	return api.xmrigService.ReadLogSession(id, tail)
+}
+
 // ClearLogs 清空日志
 func (api *MinerAPI) ClearLogs() {
 	api.xmrigService.ClearLogs()
@@ -60,9 +137,101 @@ func (api *MinerAPI) SaveConfig(config *models.XMRigConfig) error {
 	if api.xmrigService.IsRunning() {
 		return fmt.Errorf("挖矿运行中，禁止修改配置")
 	}
+	if errs := service.ValidateConfig(config); len(errs) > 0 {
+		return &service.ConfigValidationError{Errors: errs}
+	}
 	return api.configService.SaveConfig(config)
 }
 
+// ValidateConfig 校验配置，返回字段级错误供界面标注
+func (api *MinerAPI) ValidateConfig(config *models.XMRigConfig) []models.FieldError {
+	return service.ValidateConfig(config)
+}
+
+// GetConfigWarnings 返回不阻止保存的配置提醒，例如仍在使用默认钱包地址
+func (api *MinerAPI) GetConfigWarnings(config *models.XMRigConfig) []models.FieldError {
+	return service.ConfigWarnings(config)
+}
+
+// ListProfiles 列出配置方案
+func (api *MinerAPI) ListProfiles() ([]models.ProfileInfo, error) {
+	return api.configService.ListProfiles()
+}
+
+// CreateProfile 以默认配置新建配置方案
+func (api *MinerAPI) CreateProfile(name string) error {
+	return api.configService.CreateProfile(name)
+}
+
+// CloneProfile 复制配置方案
+func (api *MinerAPI) CloneProfile(source, name string) error {
+	return api.configService.CloneProfile(source, name)
+}
+
+// RenameProfile 重命名配置方案
+func (api *MinerAPI) RenameProfile(oldName, newName string) error {
+	return api.configService.RenameProfile(oldName, newName)
+}
+
+// DeleteProfile 删除配置方案
+func (api *MinerAPI) DeleteProfile(name string) error {
+	return api.configService.DeleteProfile(name)
+}
+
+// ActivateProfile 切换当前配置方案。未在挖矿时直接切换；挖矿运行中时，
+// restart 为 false 则拒绝切换，为 true 则校验新方案后重启挖矿使其生效
+func (api *MinerAPI) ActivateProfile(name string, restart bool) error {
+	running := api.xmrigService.IsRunning()
+	if running && !restart {
+		return fmt.Errorf("挖矿运行中，切换配置方案需要重启挖矿")
+	}
+	if running {
+		cfg, err := api.configService.LoadProfileConfig(name)
+		if err != nil {
+			return err
+		}
+		if errs := service.ValidateConfig(cfg); len(errs) > 0 {
+			return &service.ConfigValidationError{Errors: errs}
+		}
+	}
+
+	if err := api.configService.SetActiveProfile(name); err != nil {
+		return err
+	}
+	api.xmrigService.Notify(models.LogInfo, fmt.Sprintf("已切换到配置方案 %s", name))
+	if !running {
+		return nil
+	}
+	if err := api.supervisor.Stop(); err != nil {
+		return err
+	}
+	return api.supervisor.Start()
+}
+
+// GetSchedule 获取定时挖矿配置
+func (api *MinerAPI) GetSchedule() (models.ScheduleConfig, error) {
+	return api.configService.LoadSchedule()
+}
+
+// SaveSchedule 保存定时挖矿配置并立即按新规则调度，挖矿运行中也可修改
+func (api *MinerAPI) SaveSchedule(cfg models.ScheduleConfig) error {
+	if err := api.configService.SaveSchedule(cfg); err != nil {
+		return err
+	}
+	api.scheduler.Reload()
+	return nil
+}
+
+// GetScheduleStatus 获取当前生效的定时规则与下一次切换
+func (api *MinerAPI) GetScheduleStatus() models.ScheduleStatus {
+	return api.scheduler.Status()
+}
+
+// GetGovernorStatus 获取负载调节状态与最近一次采样的 CPU 占用
+func (api *MinerAPI) GetGovernorStatus() models.GovernorStatus {
+	return api.governor.Status()
+}
+
 // GetDefaultConfig 获取默认配置
 func (api *MinerAPI) GetDefaultConfig() *models.XMRigConfig {
 	return api.configService.GetDefaultConfig()
@@ -0,0 +1,261 @@
+package daemon
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go-wails/internal/models"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Client 守护进程控制接口客户端
+type Client struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+// NewClient 创建客户端
+func NewClient(addr, token string) *Client {
+	return &Client{
+		baseURL: "http://" + addr,
+		token:   token,
+		http:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Start 开始挖矿
+func (c *Client) Start() error {
+	return c.do(http.MethodPost, "/start", nil, nil)
+}
+
+// Stop 停止挖矿
+func (c *Client) Stop() error {
+	return c.do(http.MethodPost, "/stop", nil, nil)
+}
+
+// Status 获取挖矿状态
+func (c *Client) Status() (*models.MinerStatus, error) {
+	var status models.MinerStatus
+	if err := c.do(http.MethodGet, "/status", nil, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// SystemInfo 获取系统信息
+func (c *Client) SystemInfo() (*models.SystemInfo, error) {
+	var info models.SystemInfo
+	if err := c.do(http.MethodGet, "/system", nil, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// PoolSelection 获取最近一次矿池选择结果，probe 为真时立即重新探测
+func (c *Client) PoolSelection(probe bool) (*models.PoolSelection, error) {
+	var selection *models.PoolSelection
+	var err error
+	if probe {
+		err = c.do(http.MethodPost, "/pools/probe", nil, &selection)
+	} else {
+		err = c.do(http.MethodGet, "/pools", nil, &selection)
+	}
+	return selection, err
+}
+
+// History 获取算力历史
+func (c *Client) History(rangeSeconds, resolutionSeconds int64) ([]models.HistorySample, error) {
+	var samples []models.HistorySample
+	path := fmt.Sprintf("/history?range=%d&resolution=%d", rangeSeconds, resolutionSeconds)
+	if err := c.do(http.MethodGet, path, nil, &samples); err != nil {
+		return nil, err
+	}
+	return samples, nil
+}
+
+// RestartHistory 获取自动重启记录
+func (c *Client) RestartHistory() ([]models.RestartRecord, error) {
+	var history []models.RestartRecord
+	if err := c.do(http.MethodGet, "/restarts", nil, &history); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// Logs 获取日志
+func (c *Client) Logs() ([]models.LogRecord, error) {
+	var logs []models.LogRecord
+	if err := c.do(http.MethodGet, "/logs", nil, &logs); err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+// LogSessions 列出日志文件中保存的历次运行
+func (c *Client) LogSessions() ([]models.LogSession, error) {
+	var sessions []models.LogSession
+	if err := c.do(http.MethodGet, "/logs/sessions", nil, &sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// ReadLogSession 读取一次运行的日志文件，tail 大于 0 时只返回最后 tail 行
+func (c *Client) ReadLogSession(id string, tail int) ([]string, error) {
+	var lines []string
+	path := fmt.Sprintf("/logs/session?id=%s&tail=%d", url.QueryEscape(id), tail)
+	if err := c.do(http.MethodGet, path, nil, &lines); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// QueryLogs 按条件查询日志
+func (c *Client) QueryLogs(query models.LogQuery) (*models.LogPage, error) {
+	var page models.LogPage
+	if err := c.do(http.MethodPost, "/logs/query", query, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// ClearLogs 清空日志
+func (c *Client) ClearLogs() error {
+	return c.do(http.MethodPost, "/logs/clear", nil, nil)
+}
+
+// LoadConfig 读取配置
+func (c *Client) LoadConfig() (*models.XMRigConfig, error) {
+	var cfg models.XMRigConfig
+	if err := c.do(http.MethodGet, "/config", nil, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// SaveConfig 保存配置
+func (c *Client) SaveConfig(cfg *models.XMRigConfig) error {
+	return c.do(http.MethodPut, "/config", cfg, nil)
+}
+
+// Schedule 读取定时规则
+func (c *Client) Schedule() (*models.ScheduleConfig, error) {
+	var cfg models.ScheduleConfig
+	if err := c.do(http.MethodGet, "/schedule", nil, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// SaveSchedule 保存定时规则
+func (c *Client) SaveSchedule(cfg *models.ScheduleConfig) error {
+	return c.do(http.MethodPut, "/schedule", cfg, nil)
+}
+
+// ScheduleStatus 获取定时状态与下一次切换
+func (c *Client) ScheduleStatus() (*models.ScheduleStatus, error) {
+	var status models.ScheduleStatus
+	if err := c.do(http.MethodGet, "/schedule/status", nil, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// GovernorStatus 获取负载调节状态
+func (c *Client) GovernorStatus() (*models.GovernorStatus, error) {
+	var status models.GovernorStatus
+	if err := c.do(http.MethodGet, "/governor", nil, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// ExportDiagnostics 导出诊断包到守护进程的数据目录，返回写入的路径
+func (c *Client) ExportDiagnostics() (string, error) {
+	var written string
+	if err := c.do(http.MethodPost, "/diagnostics", nil, &written); err != nil {
+		return "", err
+	}
+	return written, nil
+}
+
+// Profiles 列出配置方案
+func (c *Client) Profiles() ([]models.ProfileInfo, error) {
+	var profiles []models.ProfileInfo
+	if err := c.do(http.MethodGet, "/profiles", nil, &profiles); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// CreateProfile 新建配置方案
+func (c *Client) CreateProfile(name string) error {
+	return c.do(http.MethodPost, "/profiles/create", ProfileRequest{Name: name}, nil)
+}
+
+// CloneProfile 复制配置方案
+func (c *Client) CloneProfile(source, name string) error {
+	return c.do(http.MethodPost, "/profiles/clone", ProfileRequest{Name: source, NewName: name}, nil)
+}
+
+// RenameProfile 重命名配置方案
+func (c *Client) RenameProfile(oldName, newName string) error {
+	return c.do(http.MethodPost, "/profiles/rename", ProfileRequest{Name: oldName, NewName: newName}, nil)
+}
+
+// DeleteProfile 删除配置方案
+func (c *Client) DeleteProfile(name string) error {
+	return c.do(http.MethodPost, "/profiles/delete", ProfileRequest{Name: name}, nil)
+}
+
+// ActivateProfile 切换配置方案
+func (c *Client) ActivateProfile(name string, restart bool) error {
+	return c.do(http.MethodPost, "/profiles/activate", ProfileRequest{Name: name, Restart: restart}, nil)
+}
+
+// do 发送请求并解析统一响应
+func (c *Client) do(method, path string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("序列化请求失败: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil || method == http.MethodPost || method == http.MethodPut {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("连接守护进程失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var r response
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return fmt.Errorf("解析响应失败: %w", err)
+	}
+	if r.Error != "" {
+		return fmt.Errorf("%s", r.Error)
+	}
+	if out != nil && len(r.Result) > 0 {
+		if err := json.Unmarshal(r.Result, out); err != nil {
+			return fmt.Errorf("解析响应失败: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,345 @@
+package daemon
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-wails/internal/api"
+	"go-wails/internal/models"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// DefaultAddr 守护进程默认监听地址
+const DefaultAddr = "127.0.0.1:3650"
+
+// Server 守护进程控制接口，供命令行客户端调用
+type Server struct {
+	minerAPI *api.MinerAPI
+	token    string
+	port     string // 实际监听的端口，用于校验 Host
+	server   *http.Server
+}
+
+// NewServer 创建控制接口服务，token 不能为空，可通过 LoadOrCreateToken 获取
+func NewServer(minerAPI *api.MinerAPI, addr, token string) *Server {
+	s := &Server{
+		minerAPI: minerAPI,
+		token:    token,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/start", s.post(func(r *http.Request) (interface{}, error) {
+		return nil, s.minerAPI.StartMining()
+	}))
+	mux.HandleFunc("/stop", s.post(func(r *http.Request) (interface{}, error) {
+		return nil, s.minerAPI.StopMining()
+	}))
+	mux.HandleFunc("/status", s.get(func(r *http.Request) (interface{}, error) {
+		return s.minerAPI.GetMinerStatus()
+	}))
+	mux.HandleFunc("/system", s.get(func(r *http.Request) (interface{}, error) {
+		return s.minerAPI.GetSystemInfo()
+	}))
+	mux.HandleFunc("/pools", s.get(func(r *http.Request) (interface{}, error) {
+		return s.minerAPI.GetPoolSelection(), nil
+	}))
+	mux.HandleFunc("/pools/probe", s.post(func(r *http.Request) (interface{}, error) {
+		return s.minerAPI.ProbePools()
+	}))
+	mux.HandleFunc("/history", s.get(func(r *http.Request) (interface{}, error) {
+		rangeSeconds, err := queryInt(r, "range", 3600)
+		if err != nil {
+			return nil, err
+		}
+		resolution, err := queryInt(r, "resolution", 0)
+		if err != nil {
+			return nil, err
+		}
+		return s.minerAPI.GetHistory(rangeSeconds, resolution)
+	}))
+	mux.HandleFunc("/restarts", s.get(func(r *http.Request) (interface{}, error) {
+		return s.minerAPI.GetRestartHistory(), nil
+	}))
+	mux.HandleFunc("/logs", s.get(func(r *http.Request) (interface{}, error) {
+		return s.minerAPI.GetLogs(), nil
+	}))
+	mux.HandleFunc("/logs/query", s.post(func(r *http.Request) (interface{}, error) {
+		var query models.LogQuery
+		if err := json.NewDecoder(r.Body).Decode(&query); err != nil {
+			return nil, fmt.Errorf("解析请求失败: %w", err)
+		}
+		return s.minerAPI.QueryLogs(query)
+	}))
+	mux.HandleFunc("/logs/sessions", s.get(func(r *http.Request) (interface{}, error) {
+		return s.minerAPI.ListLogSessions()
+	}))
+	mux.HandleFunc("/logs/session", s.get(func(r *http.Request) (interface{}, error) {
+		tail, err := queryInt(r, "tail", 0)
+		if err != nil {
+			return nil, err
+		}
+		return s.minerAPI.ReadLogSession(r.URL.Query().Get("id"), int(tail))
+	}))
+	mux.HandleFunc("/logs/clear", s.post(func(r *http.Request) (interface{}, error) {
+		s.minerAPI.ClearLogs()
+		return nil, nil
+	}))
+	mux.HandleFunc("/config", s.handleConfig)
+	mux.HandleFunc("/schedule", s.handleSchedule)
+	mux.HandleFunc("/schedule/status", s.get(func(r *http.Request) (interface{}, error) {
+		return s.minerAPI.GetScheduleStatus(), nil
+	}))
+	mux.HandleFunc("/governor", s.get(func(r *http.Request) (interface{}, error) {
+		return s.minerAPI.GetGovernorStatus(), nil
+	}))
+	mux.HandleFunc("/diagnostics", s.post(func(r *http.Request) (interface{}, error) {
+		// 只写入数据目录，不接受客户端指定的路径
+		return s.minerAPI.ExportDiagnostics("")
+	}))
+	mux.HandleFunc("/profiles", s.get(func(r *http.Request) (interface{}, error) {
+		return s.minerAPI.ListProfiles()
+	}))
+	mux.HandleFunc("/profiles/create", s.post(func(r *http.Request) (interface{}, error) {
+		req, err := decodeProfileRequest(r)
+		if err != nil {
+			return nil, err
+		}
+		return nil, s.minerAPI.CreateProfile(req.Name)
+	}))
+	mux.HandleFunc("/profiles/clone", s.post(func(r *http.Request) (interface{}, error) {
+		req, err := decodeProfileRequest(r)
+		if err != nil {
+			return nil, err
+		}
+		return nil, s.minerAPI.CloneProfile(req.Name, req.NewName)
+	}))
+	mux.HandleFunc("/profiles/rename", s.post(func(r *http.Request) (interface{}, error) {
+		req, err := decodeProfileRequest(r)
+		if err != nil {
+			return nil, err
+		}
+		return nil, s.minerAPI.RenameProfile(req.Name, req.NewName)
+	}))
+	mux.HandleFunc("/profiles/delete", s.post(func(r *http.Request) (interface{}, error) {
+		req, err := decodeProfileRequest(r)
+		if err != nil {
+			return nil, err
+		}
+		return nil, s.minerAPI.DeleteProfile(req.Name)
+	}))
+	mux.HandleFunc("/profiles/activate", s.post(func(r *http.Request) (interface{}, error) {
+		req, err := decodeProfileRequest(r)
+		if err != nil {
+			return nil, err
+		}
+		return nil, s.minerAPI.ActivateProfile(req.Name, req.Restart)
+	}))
+
+	s.server = &http.Server{
+		Addr:              addr,
+		Handler:           s.authenticate(mux),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	return s
+}
+
+// ListenAndServe 开始监听，直到 Shutdown 被调用
+func (s *Server) ListenAndServe() error {
+	if s.token == "" {
+		return fmt.Errorf("未设置控制接口令牌")
+	}
+	if err := CheckListenAddr(s.server.Addr); err != nil {
+		return err
+	}
+	ln, err := net.Listen("tcp", s.server.Addr)
+	if err != nil {
+		return fmt.Errorf("监听控制地址失败: %w", err)
+	}
+	_, s.port, _ = net.SplitHostPort(ln.Addr().String())
+	if err := s.server.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// CheckListenAddr 控制接口为明文 HTTP，且只接受本机 Host，因此只允许监听本机地址
+func CheckListenAddr(addr string) error {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("控制地址无效 %s: %w", addr, err)
+	}
+	if host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return nil
+	}
+	return fmt.Errorf("控制接口只能监听本机地址（如 %s），不能使用 %s", DefaultAddr, addr)
+}
+
+// Shutdown 关闭控制接口
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
+
+// authenticate 校验 Host、请求类型与 Bearer 令牌。
+// 只接受本机地址作为 Host 以防 DNS 重绑定，写操作要求 JSON 请求体以防跨站表单提交
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.allowedHost(r.Host) {
+			writeError(w, http.StatusForbidden, fmt.Errorf("不允许的 Host: %s", r.Host))
+			return
+		}
+		if r.Method == http.MethodPost || r.Method == http.MethodPut {
+			mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			if err != nil || mediaType != "application/json" {
+				writeError(w, http.StatusUnsupportedMediaType, fmt.Errorf("请求类型必须为 application/json"))
+				return
+			}
+		}
+		got := r.Header.Get("Authorization")
+		want := "Bearer " + s.token
+		if s.token == "" || subtle.ConstantTimeCompare([]byte(got), []byte(want)) != 1 {
+			writeError(w, http.StatusUnauthorized, fmt.Errorf("令牌无效"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// allowedHost Host 必须是本机地址加监听端口
+func (s *Server) allowedHost(host string) bool {
+	name, port, err := net.SplitHostPort(host)
+	if err != nil || port != s.port {
+		return false
+	}
+	switch name {
+	case "127.0.0.1", "localhost", "::1":
+		return true
+	}
+	return false
+}
+
+// handleConfig GET 读取配置，PUT 保存配置
+func (s *Server) handleConfig(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		cfg, err := s.minerAPI.LoadConfig()
+		writeResult(w, cfg, err)
+	case http.MethodPut:
+		var cfg models.XMRigConfig
+		if err := json.NewDecoder(r.Body).Decode(&cfg); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("解析配置失败: %w", err))
+			return
+		}
+		writeResult(w, nil, s.minerAPI.SaveConfig(&cfg))
+	default:
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("不支持的请求方法: %s", r.Method))
+	}
+}
+
+// handleSchedule GET 读取定时规则，PUT 保存定时规则
+func (s *Server) handleSchedule(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		cfg, err := s.minerAPI.GetSchedule()
+		writeResult(w, cfg, err)
+	case http.MethodPut:
+		var cfg models.ScheduleConfig
+		if err := json.NewDecoder(r.Body).Decode(&cfg); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("解析定时规则失败: %w", err))
+			return
+		}
+		writeResult(w, nil, s.minerAPI.SaveSchedule(cfg))
+	default:
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("不支持的请求方法: %s", r.Method))
+	}
+}
+
+// ProfileRequest 配置方案操作请求
+type ProfileRequest struct {
+	Name    string `json:"name"`
+	NewName string `json:"new-name,omitempty"` // 复制或重命名后的名称
+	Restart bool   `json:"restart,omitempty"`  // 挖矿运行中切换时是否重启
+}
+
+func decodeProfileRequest(r *http.Request) (*ProfileRequest, error) {
+	var req ProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, fmt.Errorf("解析请求失败: %w", err)
+	}
+	return &req, nil
+}
+
+type handlerFunc func(r *http.Request) (interface{}, error)
+
+func (s *Server) get(h handlerFunc) http.HandlerFunc {
+	return s.method(http.MethodGet, h)
+}
+
+func (s *Server) post(h handlerFunc) http.HandlerFunc {
+	return s.method(http.MethodPost, h)
+}
+
+func (s *Server) method(method string, h handlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method {
+			writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("不支持的请求方法: %s", r.Method))
+			return
+		}
+		result, err := h(r)
+		writeResult(w, result, err)
+	}
+}
+
+// queryInt 读取整数查询参数，缺省时返回默认值
+func queryInt(r *http.Request, name string, fallback int64) (int64, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return fallback, nil
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("参数 %s 无效: %s", name, v)
+	}
+	return n, nil
+}
+
+// response 控制接口统一响应
+type response struct {
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+func writeResult(w http.ResponseWriter, result interface{}, err error) {
+	if err != nil {
+		writeError(w, http.StatusConflict, err)
+		return
+	}
+	var resp response
+	if result != nil {
+		data, err := json.Marshal(result)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		resp.Result = data
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, response{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,61 @@
+package daemon
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// tokenFileName 数据目录中保存控制接口令牌的文件
+const tokenFileName = "daemon.token"
+
+// TokenPath 返回数据目录中的令牌文件路径
+func TokenPath(dataDir string) string {
+	return filepath.Join(dataDir, tokenFileName)
+}
+
+// ReadToken 读取数据目录中的令牌，文件不存在时返回空字符串
+func ReadToken(dataDir string) (string, error) {
+	data, err := os.ReadFile(TokenPath(dataDir))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("读取令牌失败: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// LoadOrCreateToken 读取数据目录中的令牌，不存在时生成随机令牌并以仅本用户可读的权限保存
+func LoadOrCreateToken(dataDir string) (string, error) {
+	token, err := ReadToken(dataDir)
+	if err != nil || token != "" {
+		return token, err
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("生成令牌失败: %w", err)
+	}
+	token = hex.EncodeToString(buf)
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return "", fmt.Errorf("创建数据目录失败: %w", err)
+	}
+	// O_EXCL 避免覆盖同时启动的另一个守护进程写入的令牌
+	file, err := os.OpenFile(TokenPath(dataDir), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if errors.Is(err, os.ErrExist) {
+		return ReadToken(dataDir)
+	}
+	if err != nil {
+		return "", fmt.Errorf("保存令牌失败: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.WriteString(token + "\n"); err != nil {
+		return "", fmt.Errorf("保存令牌失败: %w", err)
+	}
+	return token, nil
+}
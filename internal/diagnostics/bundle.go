@@ -0,0 +1,94 @@
+// Package diagnostics 生成便于附在问题反馈中的诊断包，
+// 写入前隐藏钱包地址、密码与访问令牌。
+package diagnostics
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// errorsFileName 记录收集失败的项目
+const errorsFileName = "errors.txt"
+
+// Bundle 诊断包，按文件逐项写入 zip。
+// 单项收集失败时记录到 errors.txt 并继续，尽量保留其余内容
+type Bundle struct {
+	file    *os.File
+	zip     *zip.Writer
+	secrets []string
+	errs    []string
+}
+
+// Create 创建诊断包文件，父目录不存在时自动创建
+func Create(path string) (*Bundle, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("创建目录失败: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("创建诊断包失败: %w", err)
+	}
+	return &Bundle{file: file, zip: zip.NewWriter(file)}, nil
+}
+
+// AddConfig 隐藏敏感字段后以 JSON 写入配置，隐藏的原值会在之后写入的日志与文本中一并替换
+func (b *Bundle) AddConfig(name string, v interface{}) {
+	sanitized, secrets, err := Sanitize(v)
+	if err != nil {
+		b.AddError(name, err)
+		return
+	}
+	b.secrets = append(b.secrets, secrets...)
+	b.AddJSON(name, sanitized)
+}
+
+// AddJSON 以缩进 JSON 写入一项
+func (b *Bundle) AddJSON(name string, v interface{}) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		b.AddError(name, err)
+		return
+	}
+	b.AddText(name, string(data))
+}
+
+// AddText 写入文本，其中出现的已知敏感值会被隐藏
+func (b *Bundle) AddText(name, text string) {
+	w, err := b.zip.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: time.Now(),
+	})
+	if err != nil {
+		b.AddError(name, err)
+		return
+	}
+	if _, err := w.Write([]byte(Redact(text, b.secrets))); err != nil {
+		b.AddError(name, err)
+	}
+}
+
+// AddError 记录一项收集失败
+func (b *Bundle) AddError(name string, err error) {
+	b.errs = append(b.errs, fmt.Sprintf("%s: %v", name, err))
+}
+
+// Close 写入失败记录并关闭文件
+func (b *Bundle) Close() error {
+	if len(b.errs) > 0 {
+		b.AddText(errorsFileName, strings.Join(b.errs, "\n")+"\n")
+	}
+	err := b.zip.Close()
+	if closeErr := b.file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("写入诊断包失败: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package diagnostics
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// sensitiveKeys 需要隐藏的配置键：矿池的 user 为钱包地址
+var sensitiveKeys = map[string]bool{
+	"user":         true,
+	"pass":         true,
+	"password":     true,
+	"wallet":       true,
+	"access-token": true,
+	"token":        true,
+}
+
+// minRedactLength 短于该长度的值（如常见的密码 x）不在文本中替换，避免误伤
+const minRedactLength = 4
+
+// Sanitize 将 v 转换为 JSON 结构并隐藏敏感键的值，返回隐藏后的结构与原值
+func Sanitize(v interface{}) (interface{}, []string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, nil, fmt.Errorf("序列化配置失败: %w", err)
+	}
+	var tree interface{}
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return nil, nil, fmt.Errorf("解析配置失败: %w", err)
+	}
+	var secrets []string
+	tree = sanitizeValue(tree, &secrets)
+	return tree, secrets, nil
+}
+
+func sanitizeValue(v interface{}, secrets *[]string) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for key, child := range t {
+			if s, ok := child.(string); ok && sensitiveKeys[strings.ToLower(key)] {
+				if s != "" {
+					*secrets = append(*secrets, s)
+					t[key] = Mask(s)
+				}
+				continue
+			}
+			t[key] = sanitizeValue(child, secrets)
+		}
+	case []interface{}:
+		for i, child := range t {
+			t[i] = sanitizeValue(child, secrets)
+		}
+	}
+	return v
+}
+
+// Mask 隐藏敏感值，较长的值保留首尾各 4 个字符便于区分不同钱包
+func Mask(s string) string {
+	runes := []rune(s)
+	if len(runes) <= 12 {
+		return "***"
+	}
+	return string(runes[:4]) + "***" + string(runes[len(runes)-4:])
+}
+
+// Redact 将文本中出现的敏感值替换为隐藏后的形式
+func Redact(text string, secrets []string) string {
+	if len(secrets) == 0 {
+		return text
+	}
+	// 先替换较长的值，避免其中包含的较短值先被替换
+	sorted := append([]string(nil), secrets...)
+	sort.Slice(sorted, func(i, j int) bool { return len(sorted[i]) > len(sorted[j]) })
+	for _, s := range sorted {
+		if len(s) < minRedactLength {
+			continue
+		}
+		text = strings.ReplaceAll(text, s, Mask(s))
+	}
+	return text
+}
@@ -0,0 +1,170 @@
+// Package events 提供矿工服务的类型化事件与多订阅者事件总线，
+// 供 Wails 界面、命令行与测试各自订阅。
+package events
+
+import (
+	"go-wails/internal/models"
+	"sync"
+	"time"
+)
+
+// 事件名称，与前端监听的名称保持一致
+const (
+	TypeLog          = "miner:log"
+	TypeStarted      = "miner:started"
+	TypeStopped      = "miner:stopped"
+	TypeStatus       = "miner:status"
+	TypePoolSwitched = "miner:pool-switched"
+	TypeSchedule     = "miner:schedule"
+	TypeGovernor     = "miner:governor"
+)
+
+// subscriberBuffer 每个订阅者的事件缓冲区大小
+const subscriberBuffer = 256
+
+// Event 事件
+type Event interface {
+	Type() string
+}
+
+// LogLine 矿工输出或管理器提示的一行日志
+type LogLine struct {
+	Source string `json:"source"`
+	Line   string `json:"line"` // 已去除颜色代码
+	Time   string `json:"time"`
+	// Record 解析后的结构化日志
+	Record *models.LogRecord `json:"record,omitempty"`
+}
+
+func (LogLine) Type() string { return TypeLog }
+
+// Started 矿工进程已启动
+type Started struct {
+	PID  int       `json:"pid"`
+	Pool string    `json:"pool"`
+	Time time.Time `json:"time"`
+}
+
+func (Started) Type() string { return TypeStarted }
+
+// Stopped 矿工进程已退出
+type Stopped struct {
+	ExitCode int       `json:"exitCode"`
+	Reason   string    `json:"reason"`
+	Expected bool      `json:"expected"` // 是否由用户主动停止
+	Started  time.Time `json:"started"`  // 本次进程的启动时间
+	Time     time.Time `json:"time"`
+}
+
+func (Stopped) Type() string { return TypeStopped }
+
+// StatusChanged 运行或矿池连接状态发生变化
+type StatusChanged struct {
+	Running   bool `json:"running"`
+	Connected bool `json:"connected"`
+}
+
+func (StatusChanged) Type() string { return TypeStatus }
+
+// PoolSwitched 切换了矿池
+type PoolSwitched struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Reason string `json:"reason"`
+}
+
+func (PoolSwitched) Type() string { return TypePoolSwitched }
+
+// ScheduleChanged 定时挖矿切换到新的规则状态
+type ScheduleChanged struct {
+	Rule           string `json:"rule"`
+	Action         string `json:"action"`
+	Profile        string `json:"profile"`
+	MaxThreadsHint int    `json:"maxThreadsHint"`
+}
+
+func (ScheduleChanged) Type() string { return TypeSchedule }
+
+// GovernorChanged 负载调节让出或归还 CPU
+type GovernorChanged struct {
+	State  string  `json:"state"` // normal | throttled
+	Mode   string  `json:"mode"`
+	Load   float64 `json:"load"`
+	Reason string  `json:"reason"`
+}
+
+func (GovernorChanged) Type() string { return TypeGovernor }
+
+// Sink 事件接收者
+type Sink interface {
+	Handle(e Event)
+}
+
+// SinkFunc 函数形式的事件接收者
+type SinkFunc func(e Event)
+
+// Handle 调用函数本身
+func (f SinkFunc) Handle(e Event) { f(e) }
+
+// Publisher 事件发布者
+type Publisher interface {
+	Publish(e Event)
+}
+
+// Bus 事件总线，每个订阅者在独立的 goroutine 中按顺序接收事件，
+// 缓冲区满时丢弃该订阅者的事件，避免慢订阅者阻塞发布方
+type Bus struct {
+	mutex  sync.RWMutex
+	subs   map[int]chan Event
+	nextID int
+}
+
+// NewBus 创建事件总线
+func NewBus() *Bus {
+	return &Bus{subs: make(map[int]chan Event)}
+}
+
+// Publish 向所有订阅者发布事件
+func (b *Bus) Publish(e Event) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	for _, ch := range b.subs {
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+}
+
+// Subscribe 注册订阅者，返回取消订阅函数
+func (b *Bus) Subscribe(sink Sink) func() {
+	ch, cancel := b.Channel(subscriberBuffer)
+	go func() {
+		for e := range ch {
+			sink.Handle(e)
+		}
+	}()
+	return cancel
+}
+
+// Channel 以通道形式订阅事件，取消订阅后通道会被关闭
+func (b *Bus) Channel(buffer int) (<-chan Event, func()) {
+	ch := make(chan Event, buffer)
+
+	b.mutex.Lock()
+	id := b.nextID
+	b.nextID++
+	b.subs[id] = ch
+	b.mutex.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			b.mutex.Lock()
+			delete(b.subs, id)
+			b.mutex.Unlock()
+			close(ch)
+		})
+	}
+	return ch, cancel
+}
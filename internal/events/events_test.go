@@ -0,0 +1,153 @@
+package events
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// receive 从通道读取 n 个事件，超时失败
+func receive(t *testing.T, ch <-chan Event, n int) []Event {
+	t.Helper()
+	var got []Event
+	for len(got) < n {
+		select {
+		case e, ok := <-ch:
+			if !ok {
+				t.Fatalf("通道在收到 %d 个事件后关闭，期望 %d 个", len(got), n)
+			}
+			got = append(got, e)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("超时，只收到 %d 个事件，期望 %d 个", len(got), n)
+		}
+	}
+	return got
+}
+
+func TestBusFanOut(t *testing.T) {
+	bus := NewBus()
+	first, cancelFirst := bus.Channel(8)
+	defer cancelFirst()
+	second, cancelSecond := bus.Channel(8)
+	defer cancelSecond()
+
+	var mutex sync.Mutex
+	var handled []Event
+	done := make(chan struct{})
+	cancelSink := bus.Subscribe(SinkFunc(func(e Event) {
+		mutex.Lock()
+		handled = append(handled, e)
+		if len(handled) == 3 {
+			close(done)
+		}
+		mutex.Unlock()
+	}))
+	defer cancelSink()
+
+	want := []Event{
+		Started{PID: 1, Pool: "a"},
+		StatusChanged{Running: true},
+		Stopped{ExitCode: 1, Reason: "crash"},
+	}
+	for _, e := range want {
+		bus.Publish(e)
+	}
+
+	// 每个订阅者都按发布顺序收到全部事件
+	for i, ch := range []<-chan Event{first, second} {
+		got := receive(t, ch, len(want))
+		for j := range want {
+			if got[j] != want[j] {
+				t.Errorf("订阅者 %d 第 %d 个事件得到 %+v，期望 %+v", i+1, j+1, got[j], want[j])
+			}
+		}
+	}
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Sink 没有收到全部事件")
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	for j := range want {
+		if handled[j] != want[j] {
+			t.Errorf("Sink 第 %d 个事件得到 %+v，期望 %+v", j+1, handled[j], want[j])
+		}
+	}
+}
+
+func TestBusUnsubscribe(t *testing.T) {
+	bus := NewBus()
+	kept, cancelKept := bus.Channel(8)
+	defer cancelKept()
+	removed, cancelRemoved := bus.Channel(8)
+
+	bus.Publish(StatusChanged{Running: true})
+	cancelRemoved()
+	// 重复取消不会 panic
+	cancelRemoved()
+	bus.Publish(StatusChanged{Running: false})
+
+	// 取消前的事件仍可读出，之后通道关闭且不再收到新事件
+	if got := receive(t, removed, 1); got[0] != (StatusChanged{Running: true}) {
+		t.Errorf("取消前的事件得到 %+v", got[0])
+	}
+	if e, ok := <-removed; ok {
+		t.Errorf("取消订阅后仍收到 %+v", e)
+	}
+
+	got := receive(t, kept, 2)
+	if got[1] != (StatusChanged{Running: false}) {
+		t.Errorf("其他订阅者第 2 个事件得到 %+v", got[1])
+	}
+
+	bus.mutex.RLock()
+	n := len(bus.subs)
+	bus.mutex.RUnlock()
+	if n != 1 {
+		t.Errorf("取消后订阅者数量得到 %d，期望 1", n)
+	}
+}
+
+func TestBusDropsWhenFull(t *testing.T) {
+	bus := NewBus()
+	slow, cancelSlow := bus.Channel(2)
+	defer cancelSlow()
+	fast, cancelFast := bus.Channel(8)
+	defer cancelFast()
+
+	// 慢订阅者的缓冲区满后发布不会阻塞，超出部分被丢弃
+	published := make(chan struct{})
+	go func() {
+		for i := 1; i <= 5; i++ {
+			bus.Publish(Started{PID: i})
+		}
+		close(published)
+	}()
+	select {
+	case <-published:
+	case <-time.After(5 * time.Second):
+		t.Fatal("订阅者缓冲区满时发布被阻塞")
+	}
+
+	got := receive(t, slow, 2)
+	if got[0] != (Started{PID: 1}) || got[1] != (Started{PID: 2}) {
+		t.Errorf("慢订阅者得到 %+v，期望最早的两个事件", got)
+	}
+	select {
+	case e := <-slow:
+		t.Errorf("慢订阅者收到了本应丢弃的 %+v", e)
+	default:
+	}
+
+	// 其他订阅者不受影响
+	if got := receive(t, fast, 5); got[4] != (Started{PID: 5}) {
+		t.Errorf("其他订阅者最后一个事件得到 %+v", got[4])
+	}
+
+	// 读出后可以继续接收新事件
+	bus.Publish(Started{PID: 6})
+	if got := receive(t, slow, 1); got[0] != (Started{PID: 6}) {
+		t.Errorf("缓冲区腾出后得到 %+v，期望 PID 6", got[0])
+	}
+}
@@ -0,0 +1,242 @@
+// Package history 以“每天一个追加写文件”的方式保存算力与份额采样，
+// 超过保留天数的文件会被删除，查询时可按分辨率降采样。
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"go-wails/internal/models"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 默认参数
+const (
+	DefaultRetentionDays = 30
+	// MaxPoints 未指定分辨率时，查询结果的最大点数
+	MaxPoints = 500
+	dayLayout = "2006-01-02"
+	fileExt   = ".jsonl"
+)
+
+// Store 采样存储
+type Store struct {
+	dir           string
+	retentionDays int
+	mutex         sync.Mutex
+	file          *os.File
+	fileDay       string
+}
+
+// NewStore 创建存储，retentionDays 不大于 0 时使用默认值
+func NewStore(dir string, retentionDays int) *Store {
+	if retentionDays <= 0 {
+		retentionDays = DefaultRetentionDays
+	}
+	return &Store{dir: dir, retentionDays: retentionDays}
+}
+
+// SetRetention 修改保留天数
+func (s *Store) SetRetention(days int) {
+	if days <= 0 {
+		days = DefaultRetentionDays
+	}
+	s.mutex.Lock()
+	s.retentionDays = days
+	s.mutex.Unlock()
+}
+
+// Append 追加一条采样，跨天时切换文件并清理过期数据
+func (s *Store) Append(sample models.HistorySample) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	day := time.Unix(sample.Time, 0).Format(dayLayout)
+	if s.file == nil || s.fileDay != day {
+		if err := s.rotate(day); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.Marshal(sample)
+	if err != nil {
+		return fmt.Errorf("序列化采样失败: %w", err)
+	}
+	data = append(data, '\n')
+	if _, err := s.file.Write(data); err != nil {
+		return fmt.Errorf("写入采样失败: %w", err)
+	}
+	return nil
+}
+
+// rotate 打开指定日期的文件，调用方需持有锁
+func (s *Store) rotate(day string) error {
+	if s.file != nil {
+		s.file.Close()
+		s.file = nil
+	}
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("创建历史目录失败: %w", err)
+	}
+	f, err := os.OpenFile(filepath.Join(s.dir, day+fileExt), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("打开历史文件失败: %w", err)
+	}
+	s.file = f
+	s.fileDay = day
+	s.prune(time.Now())
+	return nil
+}
+
+// prune 删除超过保留天数的文件，调用方需持有锁
+func (s *Store) prune(now time.Time) {
+	cutoff := now.AddDate(0, 0, -s.retentionDays).Format(dayLayout)
+	for _, day := range s.days() {
+		if day < cutoff {
+			_ = os.Remove(filepath.Join(s.dir, day+fileExt))
+		}
+	}
+}
+
+// days 返回已有数据的日期，升序
+func (s *Store) days() []string {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil
+	}
+	var days []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, fileExt) {
+			continue
+		}
+		day := strings.TrimSuffix(name, fileExt)
+		if _, err := time.Parse(dayLayout, day); err == nil {
+			days = append(days, day)
+		}
+	}
+	sort.Strings(days)
+	return days
+}
+
+// Close 关闭当前文件
+func (s *Store) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+// Query 查询 [from, to] 内的采样；resolution 大于 0 时按该间隔降采样，
+// 等于 0 时自动选择分辨率使结果不超过 MaxPoints 个点
+func (s *Store) Query(from, to time.Time, resolution time.Duration) ([]models.HistorySample, error) {
+	s.mutex.Lock()
+	days := s.days()
+	s.mutex.Unlock()
+
+	fromDay := from.Format(dayLayout)
+	toDay := to.Format(dayLayout)
+	var samples []models.HistorySample
+	for _, day := range days {
+		if day < fromDay || day > toDay {
+			continue
+		}
+		daySamples, err := s.readDay(day, from.Unix(), to.Unix())
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, daySamples...)
+	}
+
+	if resolution <= 0 {
+		span := to.Sub(from)
+		// 降采样按整秒分桶，向上取整以免点数超过 MaxPoints
+		resolution = (span/MaxPoints + time.Second - 1).Truncate(time.Second)
+		if resolution <= time.Second {
+			resolution = 0
+		}
+	}
+	if resolution > 0 {
+		samples = Downsample(samples, resolution)
+	}
+	return samples, nil
+}
+
+// readDay 读取某一天在时间范围内的采样，跳过损坏的行
+func (s *Store) readDay(day string, from, to int64) ([]models.HistorySample, error) {
+	f, err := os.Open(filepath.Join(s.dir, day+fileExt))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("读取历史文件失败: %w", err)
+	}
+	defer f.Close()
+
+	var samples []models.HistorySample
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var sample models.HistorySample
+		if err := json.Unmarshal(scanner.Bytes(), &sample); err != nil {
+			continue
+		}
+		if sample.Time >= from && sample.Time <= to {
+			samples = append(samples, sample)
+		}
+	}
+	return samples, scanner.Err()
+}
+
+// Downsample 按时间桶合并采样：算力取平均值，计数类字段取桶内最后一个值，
+// 运行与连接状态只要桶内出现过即为真
+func Downsample(samples []models.HistorySample, resolution time.Duration) []models.HistorySample {
+	step := int64(resolution / time.Second)
+	if step <= 1 || len(samples) == 0 {
+		return samples
+	}
+
+	var result []models.HistorySample
+	var bucket models.HistorySample
+	var count int
+	var sumHashrate, sumHashrate60s float64
+	flush := func() {
+		if count == 0 {
+			return
+		}
+		bucket.Hashrate = sumHashrate / float64(count)
+		bucket.Hashrate60s = sumHashrate60s / float64(count)
+		result = append(result, bucket)
+	}
+
+	currentKey := int64(-1)
+	for _, sample := range samples {
+		key := sample.Time / step * step
+		if key != currentKey {
+			flush()
+			currentKey = key
+			bucket = models.HistorySample{Time: key}
+			count = 0
+			sumHashrate, sumHashrate60s = 0, 0
+		}
+		count++
+		sumHashrate += sample.Hashrate
+		sumHashrate60s += sample.Hashrate60s
+		bucket.SharesAccepted = sample.SharesAccepted
+		bucket.SharesRejected = sample.SharesRejected
+		bucket.Threads = sample.Threads
+		bucket.Pool = sample.Pool
+		bucket.Running = bucket.Running || sample.Running
+		bucket.Connected = bucket.Connected || sample.Connected
+	}
+	flush()
+	return result
+}
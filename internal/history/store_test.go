@@ -0,0 +1,208 @@
+package history
+
+import (
+	"go-wails/internal/models"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStoreQuery(t *testing.T) {
+	store := NewStore(t.TempDir(), 0)
+	defer store.Close()
+
+	// 跨越两天的采样，每分钟一条
+	start := time.Now().Add(-time.Hour).Truncate(time.Minute)
+	yesterday := start.AddDate(0, 0, -1)
+	var want []int64
+	for i := 0; i < 3; i++ {
+		ts := yesterday.Add(time.Duration(i) * time.Minute).Unix()
+		if err := store.Append(models.HistorySample{Time: ts, Running: true, Hashrate: 100}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < 10; i++ {
+		ts := start.Add(time.Duration(i) * time.Minute).Unix()
+		if err := store.Append(models.HistorySample{Time: ts, Running: true, Hashrate: float64(i)}); err != nil {
+			t.Fatal(err)
+		}
+		want = append(want, ts)
+	}
+	// 损坏的行被跳过
+	f, err := os.OpenFile(filepath.Join(store.dir, start.Format(dayLayout)+fileExt), os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString("{broken\n")
+	f.Close()
+
+	tests := []struct {
+		name       string
+		from, to   time.Time
+		resolution time.Duration
+		want       []int64
+	}{
+		{
+			name: "范围内全部采样",
+			from: start,
+			to:   start.Add(9 * time.Minute),
+			want: want,
+		},
+		{
+			name: "两端按时间截取",
+			from: start.Add(2 * time.Minute),
+			to:   start.Add(4 * time.Minute),
+			want: want[2:5],
+		},
+		{
+			name:       "跨天查询",
+			from:       yesterday,
+			to:         start.Add(time.Minute),
+			resolution: time.Second,
+			want:       append([]int64{yesterday.Unix(), yesterday.Add(time.Minute).Unix(), yesterday.Add(2 * time.Minute).Unix()}, want[:2]...),
+		},
+		{
+			name:       "按五分钟降采样",
+			from:       start,
+			to:         start.Add(9 * time.Minute),
+			resolution: 5 * time.Minute,
+			want:       bucketKeys(want, 5*60),
+		},
+		{
+			name: "范围内没有数据",
+			from: start.Add(time.Hour),
+			to:   start.Add(2 * time.Hour),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			samples, err := store.Query(tt.from, tt.to, tt.resolution)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []int64
+			for _, s := range samples {
+				got = append(got, s.Time)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("得到 %v，期望 %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("第 %d 个点得到 %d，期望 %d", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+// bucketKeys 返回时间戳按 step 秒分桶后的桶起点，去重保序
+func bucketKeys(times []int64, step int64) []int64 {
+	var keys []int64
+	for _, ts := range times {
+		key := ts / step * step
+		if len(keys) == 0 || keys[len(keys)-1] != key {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func TestStoreQueryAutoResolution(t *testing.T) {
+	store := NewStore(t.TempDir(), 0)
+	defer store.Close()
+
+	now := time.Now().Truncate(time.Second)
+	from := now.Add(-2 * time.Hour)
+	for ts := from; !ts.After(now); ts = ts.Add(5 * time.Second) {
+		if err := store.Append(models.HistorySample{Time: ts.Unix(), Running: true}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	samples, err := store.Query(from, now, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 桶按整点对齐，首尾可能各多出半个桶
+	if len(samples) == 0 || len(samples) > MaxPoints+1 {
+		t.Fatalf("自动分辨率得到 %d 个点，期望不超过 %d", len(samples), MaxPoints+1)
+	}
+}
+
+func TestDownsample(t *testing.T) {
+	samples := []models.HistorySample{
+		{Time: 60, Hashrate: 100, Hashrate60s: 10, SharesAccepted: 1, Threads: 2, Pool: "a", Running: true},
+		{Time: 90, Hashrate: 200, Hashrate60s: 30, SharesAccepted: 2, SharesRejected: 1, Threads: 4, Pool: "b", Connected: true},
+		{Time: 120, Hashrate: 50, SharesAccepted: 3},
+	}
+
+	got := Downsample(samples, time.Minute)
+	want := []models.HistorySample{
+		{Time: 60, Hashrate: 150, Hashrate60s: 20, SharesAccepted: 2, SharesRejected: 1, Threads: 4, Pool: "b", Running: true, Connected: true},
+		{Time: 120, Hashrate: 50, SharesAccepted: 3},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("得到 %+v，期望 %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("第 %d 个桶得到 %+v，期望 %+v", i, got[i], want[i])
+		}
+	}
+
+	// 分辨率不超过一秒时原样返回
+	if got := Downsample(samples, time.Second); len(got) != len(samples) {
+		t.Errorf("一秒分辨率得到 %d 个点，期望 %d", len(got), len(samples))
+	}
+}
+
+func TestStoreRetention(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	for _, days := range []int{40, 31, 29, 5} {
+		name := now.AddDate(0, 0, -days).Format(dayLayout) + fileExt
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 非数据文件不会被删除
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewStore(dir, 0)
+	defer store.Close()
+	if err := store.Append(models.HistorySample{Time: now.Unix()}); err != nil {
+		t.Fatal(err)
+	}
+	assertDays(t, store, now, 29, 5, 0)
+
+	// 缩短保留天数后，下次切换文件时清理
+	store.SetRetention(7)
+	if err := store.Append(models.HistorySample{Time: now.AddDate(0, 0, -1).Unix()}); err != nil {
+		t.Fatal(err)
+	}
+	assertDays(t, store, now, 5, 1, 0)
+	if _, err := os.Stat(filepath.Join(dir, "notes.txt")); err != nil {
+		t.Errorf("清理删除了非数据文件: %v", err)
+	}
+}
+
+// assertDays 检查存储中恰好保留了距 now 指定天数的文件
+func assertDays(t *testing.T, store *Store, now time.Time, daysAgo ...int) {
+	t.Helper()
+	var want []string
+	for _, d := range daysAgo {
+		want = append(want, now.AddDate(0, 0, -d).Format(dayLayout))
+	}
+	got := store.days()
+	if len(got) != len(want) {
+		t.Fatalf("保留的日期得到 %v，期望 %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("保留的日期得到 %v，期望 %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,482 @@
+// Package logfile 将每次启动的矿工输出写入独立的会话日志文件，按大小与时长轮转，
+// 轮转后的文件以 gzip 压缩，并按保留天数与文件数清理。
+//
+// 会话 ID 为启动时间，正在写入的文件名为 <ID>.log，轮转后依次为 <ID>.001.log.gz、<ID>.002.log.gz ...
+// 轮转时只在锁内把文件改名为 <ID>.NNN.log，压缩在后台进行，不阻塞写入方。
+package logfile
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"go-wails/internal/models"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 默认参数
+const (
+	DefaultMaxSize       = 10 << 20
+	DefaultMaxAge        = 24 * time.Hour
+	DefaultRetentionDays = 14
+	DefaultMaxFiles      = 100
+
+	idLayout  = "20060102-150405"
+	activeExt = ".log"
+)
+
+var (
+	// idPattern 会话 ID：启动时间精确到毫秒
+	idPattern = regexp.MustCompile(`^\d{8}-\d{6}-\d{3}$`)
+	// fileNamePattern 匹配会话文件名，分组为会话 ID 与分段序号
+	fileNamePattern = regexp.MustCompile(`^(\d{8}-\d{6}-\d{3})(?:\.(\d{3,}))?\.log(\.gz)?$`)
+)
+
+// sessionID 由启动时间生成会话 ID
+func sessionID(t time.Time) string {
+	return fmt.Sprintf("%s-%03d", t.Format(idLayout), t.Nanosecond()/int(time.Millisecond))
+}
+
+// sessionStart 解析会话 ID 中的启动时间
+func sessionStart(id string) (time.Time, bool) {
+	if !idPattern.MatchString(id) {
+		return time.Time{}, false
+	}
+	t, err := time.ParseInLocation(idLayout, id[:len(idLayout)], time.Local)
+	if err != nil {
+		return time.Time{}, false
+	}
+	ms, _ := strconv.Atoi(id[len(idLayout)+1:])
+	return t.Add(time.Duration(ms) * time.Millisecond), true
+}
+
+// Policy 轮转与保留策略，数值不大于 0 时使用默认值
+type Policy struct {
+	MaxSize       int64         // 单个文件的最大字节数
+	MaxAge        time.Duration // 单个文件的最长写入时长
+	RetentionDays int
+	MaxFiles      int
+}
+
+func (p Policy) withDefaults() Policy {
+	if p.MaxSize <= 0 {
+		p.MaxSize = DefaultMaxSize
+	}
+	if p.MaxAge <= 0 {
+		p.MaxAge = DefaultMaxAge
+	}
+	if p.RetentionDays <= 0 {
+		p.RetentionDays = DefaultRetentionDays
+	}
+	if p.MaxFiles <= 0 {
+		p.MaxFiles = DefaultMaxFiles
+	}
+	return p
+}
+
+// Store 会话日志存储
+type Store struct {
+	dir    string
+	policy Policy
+	mutex  sync.Mutex
+
+	id       string // 当前会话，空为没有打开的会话
+	file     *os.File
+	size     int64
+	openedAt time.Time
+
+	compressing map[string]bool // 正在后台压缩的分段路径
+	wg          sync.WaitGroup
+}
+
+// NewStore 创建存储
+func NewStore(dir string) *Store {
+	return &Store{dir: dir, policy: Policy{}.withDefaults(), compressing: map[string]bool{}}
+}
+
+// Dir 返回日志目录
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// SetPolicy 修改轮转与保留策略
+func (s *Store) SetPolicy(p Policy) {
+	s.mutex.Lock()
+	s.policy = p.withDefaults()
+	s.mutex.Unlock()
+}
+
+// Begin 结束上一个会话并开始新会话，返回会话 ID
+func (s *Store) Begin(now time.Time) (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := s.endLocked(); err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return "", fmt.Errorf("创建日志目录失败: %w", err)
+	}
+	id := sessionID(now)
+	// 上次异常退出时遗留的未压缩文件
+	s.compressStray(id)
+	if err := s.openLocked(id, now); err != nil {
+		return "", err
+	}
+	s.prune(now)
+	return id, nil
+}
+
+// WriteLine 向当前会话追加一行，没有打开的会话时忽略；超过大小或时长后先轮转
+func (s *Store) WriteLine(line string, now time.Time) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+	n := int64(len(line) + 1)
+	if s.size > 0 && (s.size+n > s.policy.MaxSize || now.Sub(s.openedAt) >= s.policy.MaxAge) {
+		if err := s.rotateLocked(now); err != nil {
+			return err
+		}
+	}
+	written, err := io.WriteString(s.file, line+"\n")
+	s.size += int64(written)
+	if err != nil {
+		return fmt.Errorf("写入日志文件失败: %w", err)
+	}
+	return nil
+}
+
+// Close 结束当前会话，轮转出的分段在后台压缩，需要等待时调用 Wait
+func (s *Store) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.endLocked()
+}
+
+// Wait 等待后台压缩完成
+func (s *Store) Wait() {
+	s.wg.Wait()
+}
+
+// Sessions 列出所有会话，最新的在前
+func (s *Store) Sessions() ([]models.LogSession, error) {
+	s.mutex.Lock()
+	current := s.id
+	s.mutex.Unlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []models.LogSession{}, nil
+		}
+		return nil, fmt.Errorf("读取日志目录失败: %w", err)
+	}
+	byID := map[string]*models.LogSession{}
+	for _, e := range entries {
+		m := fileNamePattern.FindStringSubmatch(e.Name())
+		if e.IsDir() || m == nil {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		session := byID[m[1]]
+		if session == nil {
+			start, _ := sessionStart(m[1])
+			session = &models.LogSession{ID: m[1], Start: start.Unix(), Active: m[1] == current}
+			byID[m[1]] = session
+		}
+		session.Files++
+		session.Size += info.Size()
+		if mod := info.ModTime().Unix(); mod > session.Modified {
+			session.Modified = mod
+		}
+	}
+
+	sessions := make([]models.LogSession, 0, len(byID))
+	for _, session := range byID {
+		sessions = append(sessions, *session)
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].ID > sessions[j].ID })
+	return sessions, nil
+}
+
+// Read 读取会话的全部分段，tail 大于 0 时只返回最后 tail 行
+func (s *Store) Read(id string, tail int) ([]string, error) {
+	files, err := s.sessionFiles(id)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("日志会话不存在: %s", id)
+	}
+
+	var lines []string
+	collect := func(line string) {
+		lines = append(lines, line)
+		if tail > 0 && len(lines) > 2*tail {
+			// 只保留最后 tail 行，避免读取大文件时占用过多内存
+			lines = append(lines[:0], lines[len(lines)-tail:]...)
+		}
+	}
+	for _, name := range files {
+		path := filepath.Join(s.dir, name)
+		if _, err := os.Stat(path); os.IsNotExist(err) && partNumber(name) > 0 && !strings.HasSuffix(name, ".gz") {
+			// 列出文件后分段刚好压缩完成
+			path += ".gz"
+		}
+		if err := readLines(path, collect); err != nil {
+			return nil, err
+		}
+	}
+	if tail > 0 && len(lines) > tail {
+		lines = lines[len(lines)-tail:]
+	}
+	return lines, nil
+}
+
+// sessionFiles 返回会话的文件名，按写入顺序排列，当前文件在最后。
+// 分段的压缩文件与未压缩文件同时存在时只取压缩文件
+func (s *Store) sessionFiles(id string) ([]string, error) {
+	if _, ok := sessionStart(id); !ok {
+		return nil, fmt.Errorf("无效的日志会话: %s", id)
+	}
+	matches, err := filepath.Glob(filepath.Join(s.dir, id+".*"))
+	if err != nil {
+		return nil, err
+	}
+	byPart := map[int]string{}
+	for _, p := range matches {
+		name := filepath.Base(p)
+		m := fileNamePattern.FindStringSubmatch(name)
+		if m == nil || m[2] == "" {
+			continue
+		}
+		n := partNumber(name)
+		if _, ok := byPart[n]; !ok || m[3] != "" {
+			byPart[n] = name
+		}
+	}
+	names := make([]string, 0, len(byPart)+1)
+	for _, name := range byPart {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return partNumber(names[i]) < partNumber(names[j]) })
+	if _, err := os.Stat(filepath.Join(s.dir, id+activeExt)); err == nil {
+		names = append(names, id+activeExt)
+	}
+	return names, nil
+}
+
+// openLocked 打开会话的当前文件，调用方需持有锁
+func (s *Store) openLocked(id string, now time.Time) error {
+	f, err := os.OpenFile(filepath.Join(s.dir, id+activeExt), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("打开日志文件失败: %w", err)
+	}
+	s.id = id
+	s.file = f
+	s.size = 0
+	s.openedAt = now
+	return nil
+}
+
+// rotateLocked 将当前文件转为下一个分段并重新打开，调用方需持有锁
+func (s *Store) rotateLocked(now time.Time) error {
+	id := s.id
+	if err := s.endLocked(); err != nil {
+		return err
+	}
+	if err := s.openLocked(id, now); err != nil {
+		return err
+	}
+	s.prune(now)
+	return nil
+}
+
+// endLocked 关闭当前文件并转为分段，调用方需持有锁
+func (s *Store) endLocked() error {
+	if s.file == nil {
+		return nil
+	}
+	id := s.id
+	err := s.file.Close()
+	s.file = nil
+	s.id = ""
+	if err != nil {
+		return fmt.Errorf("关闭日志文件失败: %w", err)
+	}
+	return s.compress(id)
+}
+
+// compress 将会话的当前文件改名为下一个分段并在后台压缩，调用方需持有锁
+func (s *Store) compress(id string) error {
+	src := filepath.Join(s.dir, id+activeExt)
+	info, err := os.Stat(src)
+	if err != nil {
+		return nil
+	}
+	if info.Size() == 0 {
+		return os.Remove(src)
+	}
+	files, err := s.sessionFiles(id)
+	if err != nil {
+		return err
+	}
+	// 当前文件的序号为 0，不影响结果
+	next := 1
+	for _, name := range files {
+		if n := partNumber(name); n >= next {
+			next = n + 1
+		}
+	}
+	part := filepath.Join(s.dir, fmt.Sprintf("%s.%03d%s", id, next, activeExt))
+	if err := os.Rename(src, part); err != nil {
+		return fmt.Errorf("轮转日志文件失败: %w", err)
+	}
+	s.compressLater(part)
+	return nil
+}
+
+// compressLater 在后台将未压缩的分段压缩为 .gz 后删除原文件，调用方需持有锁
+func (s *Store) compressLater(path string) {
+	if s.compressing[path] {
+		return
+	}
+	s.compressing[path] = true
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if err := gzipFile(path, path+".gz"); err == nil {
+			_ = os.Remove(path)
+		}
+		s.mutex.Lock()
+		delete(s.compressing, path)
+		s.mutex.Unlock()
+	}()
+}
+
+// compressStray 压缩除 current 外遗留的未压缩文件，包括上次未压缩完的分段，调用方需持有锁
+func (s *Store) compressStray(current string) {
+	matches, _ := filepath.Glob(filepath.Join(s.dir, "*"+activeExt))
+	for _, path := range matches {
+		m := fileNamePattern.FindStringSubmatch(filepath.Base(path))
+		if m == nil || m[1] == current {
+			continue
+		}
+		if m[2] == "" {
+			_ = s.compress(m[1])
+		} else {
+			s.compressLater(path)
+		}
+	}
+}
+
+// prune 删除超过保留天数的分段，文件总数超出上限时从最旧的开始删除，当前文件与正在压缩的分段不会被删除
+func (s *Store) prune(now time.Time) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return
+	}
+	type entry struct {
+		name string
+		mod  time.Time
+	}
+	var files []entry
+	for _, e := range entries {
+		if e.IsDir() || e.Name() == s.id+activeExt || !fileNamePattern.MatchString(e.Name()) ||
+			s.compressing[filepath.Join(s.dir, e.Name())] {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, entry{e.Name(), info.ModTime()})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].mod.Before(files[j].mod) })
+
+	cutoff := now.AddDate(0, 0, -s.policy.RetentionDays)
+	// 当前文件占用一个名额
+	excess := len(files) + 1 - s.policy.MaxFiles
+	for _, f := range files {
+		if excess > 0 || f.mod.Before(cutoff) {
+			_ = os.Remove(filepath.Join(s.dir, f.name))
+			excess--
+		}
+	}
+}
+
+// partNumber 返回分段序号，当前文件返回 0
+func partNumber(name string) int {
+	m := fileNamePattern.FindStringSubmatch(name)
+	if m == nil || m[2] == "" {
+		return 0
+	}
+	n, _ := strconv.Atoi(m[2])
+	return n
+}
+
+// gzipFile 将 src 压缩到 dst，先写临时文件再改名
+func gzipFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("压缩日志失败: %w", err)
+	}
+	defer in.Close()
+
+	tmp := dst + ".tmp"
+	out, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("压缩日志失败: %w", err)
+	}
+	zw := gzip.NewWriter(out)
+	_, err = io.Copy(zw, in)
+	if cerr := zw.Close(); err == nil {
+		err = cerr
+	}
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("压缩日志失败: %w", err)
+	}
+	return os.Rename(tmp, dst)
+}
+
+// readLines 逐行读取文件，.gz 文件自动解压
+func readLines(path string, fn func(line string)) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("读取日志文件失败: %w", err)
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			return fmt.Errorf("解压日志文件失败: %w", err)
+		}
+		defer zr.Close()
+		r = zr
+	}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		fn(scanner.Text())
+	}
+	return scanner.Err()
+}
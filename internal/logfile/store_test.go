@@ -0,0 +1,341 @@
+package logfile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// listDir 返回目录中的文件名，升序
+func listDir(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+// writeLines 依次写入 line1、line2 ...，每行间隔 step
+func writeLines(t *testing.T, s *Store, start time.Time, step time.Duration, n int) {
+	t.Helper()
+	for i := 1; i <= n; i++ {
+		if err := s.WriteLine(fmt.Sprintf("line%d", i), start.Add(time.Duration(i-1)*step)); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func equalLines(got, want []string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestStoreRotate(t *testing.T) {
+	start := time.Date(2026, time.October, 16, 12, 0, 0, 0, time.Local)
+	tests := []struct {
+		name   string
+		policy Policy
+		step   time.Duration
+		lines  int
+		want   []string // 写完并关闭会话前的文件
+	}{
+		{
+			// 每行 6 字节，每个文件最多两行
+			name:   "按大小轮转",
+			policy: Policy{MaxSize: 12},
+			lines:  5,
+			want:   []string{".001.log.gz", ".002.log.gz", ".log"},
+		},
+		{
+			name:   "按时长轮转",
+			policy: Policy{MaxAge: time.Hour},
+			step:   40 * time.Minute,
+			lines:  4,
+			want:   []string{".001.log.gz", ".log"},
+		},
+		{
+			name:  "未达到上限不轮转",
+			step:  time.Minute,
+			lines: 5,
+			want:  []string{".log"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewStore(t.TempDir())
+			s.SetPolicy(tt.policy)
+			id, err := s.Begin(start)
+			if err != nil {
+				t.Fatal(err)
+			}
+			writeLines(t, s, start, tt.step, tt.lines)
+			s.Wait()
+
+			var want []string
+			for _, suffix := range tt.want {
+				want = append(want, id+suffix)
+			}
+			if got := listDir(t, s.Dir()); !equalLines(got, want) {
+				t.Fatalf("文件得到 %v，期望 %v", got, want)
+			}
+
+			// 跨分段读取，顺序与写入一致
+			var all []string
+			for i := 1; i <= tt.lines; i++ {
+				all = append(all, fmt.Sprintf("line%d", i))
+			}
+			lines, err := s.Read(id, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !equalLines(lines, all) {
+				t.Fatalf("读取得到 %v，期望 %v", lines, all)
+			}
+
+			// 关闭后当前文件也转为压缩分段
+			if err := s.Close(); err != nil {
+				t.Fatal(err)
+			}
+			s.Wait()
+			for _, name := range listDir(t, s.Dir()) {
+				if !strings.HasSuffix(name, ".log.gz") {
+					t.Errorf("关闭后仍有未压缩的文件 %s", name)
+				}
+			}
+			if lines, err := s.Read(id, 0); err != nil || !equalLines(lines, all) {
+				t.Errorf("关闭后读取得到 %v (%v)，期望 %v", lines, err, all)
+			}
+		})
+	}
+}
+
+func TestStoreReadMixedParts(t *testing.T) {
+	dir := t.TempDir()
+	id := sessionID(time.Date(2026, time.October, 16, 12, 0, 0, 0, time.Local))
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	gzip := func(name, content string) {
+		t.Helper()
+		write(name, content)
+		if err := gzipFile(filepath.Join(dir, name), filepath.Join(dir, name+".gz")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	gzip(id+".001.log", "a\nb\n")
+	os.Remove(filepath.Join(dir, id+".001.log"))
+	// 分段 2 压缩到一半时两个文件同时存在，只读取压缩文件
+	gzip(id+".002.log", "c\n")
+	// 分段 10 尚未压缩，序号按数值排序
+	write(id+".010.log", "e\n")
+	gzip(id+".003.log", "d\n")
+	os.Remove(filepath.Join(dir, id+".003.log"))
+	write(id+".log", "f\ng\n")
+	// 其他会话与无关文件不影响结果
+	write(sessionID(time.Date(2026, time.October, 15, 12, 0, 0, 0, time.Local))+".log", "other\n")
+	write("notes.txt", "x\n")
+
+	s := NewStore(dir)
+	tests := []struct {
+		name string
+		tail int
+		want []string
+	}{
+		{name: "全部", want: []string{"a", "b", "c", "d", "e", "f", "g"}},
+		{name: "最后三行", tail: 3, want: []string{"e", "f", "g"}},
+		{name: "最后一行", tail: 1, want: []string{"g"}},
+		{name: "行数多于日志", tail: 100, want: []string{"a", "b", "c", "d", "e", "f", "g"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, err := s.Read(id, tt.tail)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !equalLines(lines, tt.want) {
+				t.Fatalf("得到 %v，期望 %v", lines, tt.want)
+			}
+		})
+	}
+
+	if _, err := s.Read(sessionID(time.Date(2026, time.October, 1, 0, 0, 0, 0, time.Local)), 0); err == nil {
+		t.Error("读取不存在的会话应当返回错误")
+	}
+	if _, err := s.Read("../"+id, 0); err == nil {
+		t.Error("读取无效的会话 ID 应当返回错误")
+	}
+}
+
+func TestStoreReadTailLargeSession(t *testing.T) {
+	s := NewStore(t.TempDir())
+	s.SetPolicy(Policy{MaxSize: 100})
+	start := time.Date(2026, time.October, 16, 12, 0, 0, 0, time.Local)
+	id, err := s.Begin(start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	writeLines(t, s, start, 0, 500)
+	s.Wait()
+
+	lines, err := s.Read(id, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"line494", "line495", "line496", "line497", "line498", "line499", "line500"}
+	if !equalLines(lines, want) {
+		t.Fatalf("得到 %v，期望 %v", lines, want)
+	}
+}
+
+func TestStorePrune(t *testing.T) {
+	now := time.Date(2026, time.October, 16, 12, 0, 0, 0, time.Local)
+	old := now.AddDate(0, 0, -30)
+	session := func(days int) string {
+		return sessionID(now.AddDate(0, 0, -days))
+	}
+
+	tests := []struct {
+		name   string
+		policy Policy
+		files  map[string]time.Time // 文件名与修改时间
+		want   []string
+	}{
+		{
+			name:   "删除超过保留天数的文件",
+			policy: Policy{RetentionDays: 14},
+			files: map[string]time.Time{
+				session(30) + ".001.log.gz": old,
+				session(30) + ".002.log.gz": old,
+				session(2) + ".001.log.gz":  now.AddDate(0, 0, -2),
+			},
+			want: []string{session(2) + ".001.log.gz"},
+		},
+		{
+			name:   "超过文件数时从最旧的开始删除",
+			policy: Policy{MaxFiles: 3},
+			files: map[string]time.Time{
+				session(4) + ".001.log.gz": now.AddDate(0, 0, -4),
+				session(3) + ".001.log.gz": now.AddDate(0, 0, -3),
+				session(2) + ".001.log.gz": now.AddDate(0, 0, -2),
+				session(1) + ".001.log.gz": now.AddDate(0, 0, -1),
+			},
+			want: []string{session(1) + ".001.log.gz", session(2) + ".001.log.gz"},
+		},
+		{
+			name:   "正在压缩的分段不删除",
+			policy: Policy{RetentionDays: 14, MaxFiles: 2},
+			files: map[string]time.Time{
+				session(30) + ".001.log":    old,
+				session(20) + ".001.log.gz": now.AddDate(0, 0, -20),
+				session(1) + ".001.log.gz":  now.AddDate(0, 0, -1),
+			},
+			want: []string{session(1) + ".001.log.gz", session(30) + ".001.log"},
+		},
+		{
+			name:   "不删除无关文件",
+			policy: Policy{RetentionDays: 1},
+			files:  map[string]time.Time{"notes.txt": old, "bad.log.gz": old},
+			want:   []string{"bad.log.gz", "notes.txt"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			s := NewStore(dir)
+			s.SetPolicy(tt.policy)
+			id, err := s.Begin(now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer s.Close()
+			// 当前文件即使很旧也不会被删除
+			if err := s.WriteLine("active", now); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.Chtimes(filepath.Join(dir, id+activeExt), old, old); err != nil {
+				t.Fatal(err)
+			}
+			for name, mod := range tt.files {
+				path := filepath.Join(dir, name)
+				if err := os.WriteFile(path, []byte("x\n"), 0644); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.Chtimes(path, mod, mod); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			s.mutex.Lock()
+			// 模拟后台压缩尚未完成的分段
+			for name := range tt.files {
+				if strings.HasSuffix(name, ".001.log") {
+					s.compressing[filepath.Join(dir, name)] = true
+				}
+			}
+			s.prune(now)
+			s.compressing = map[string]bool{}
+			s.mutex.Unlock()
+
+			want := append([]string{id + activeExt}, tt.want...)
+			sort.Strings(want)
+			if got := listDir(t, dir); !equalLines(got, want) {
+				t.Fatalf("保留的文件得到 %v，期望 %v", got, want)
+			}
+		})
+	}
+}
+
+func TestStoreSessions(t *testing.T) {
+	s := NewStore(t.TempDir())
+	s.SetPolicy(Policy{MaxSize: 12})
+	first := time.Date(2026, time.October, 16, 12, 0, 0, 0, time.Local)
+	firstID, err := s.Begin(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeLines(t, s, first, 0, 3)
+	second := first.Add(time.Hour)
+	secondID, err := s.Begin(second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	writeLines(t, s, second, 0, 1)
+	s.Wait()
+
+	sessions, err := s.Sessions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("得到 %d 个会话，期望 2", len(sessions))
+	}
+	if sessions[0].ID != secondID || !sessions[0].Active || sessions[0].Files != 1 || sessions[0].Start != second.Unix() {
+		t.Errorf("最新会话得到 %+v", sessions[0])
+	}
+	if sessions[1].ID != firstID || sessions[1].Active || sessions[1].Files != 2 {
+		t.Errorf("上一个会话得到 %+v", sessions[1])
+	}
+}
@@ -1,14 +1,81 @@
 package models
 
+import (
+	"encoding/json"
+	"fmt"
+)
+
 // XMRigConfig XMRig配置结构
 type XMRigConfig struct {
-	API      APIConfig     `json:"api"`
-	HTTP     HTTPConfig    `json:"http"`
-	Autosave bool          `json:"autosave"`
-	CPU      CPUConfig     `json:"cpu"`
-	Pools    []PoolConfig  `json:"pools"`
-	RandomX  RandomXConfig `json:"randomx"`
-	LogFile  *string       `json:"log-file"`
+	API            APIConfig     `json:"api"`
+	HTTP           HTTPConfig    `json:"http"`
+	Autosave       bool          `json:"autosave"`
+	CPU            CPUConfig     `json:"cpu"`
+	Pools          []PoolConfig  `json:"pools"`
+	RandomX        RandomXConfig `json:"randomx"`
+	LogFile        *string       `json:"log-file"`
+	PauseOnBattery bool          `json:"pause-on-battery"` // 使用电池供电时暂停挖矿
+	PauseOnActive  IdleSeconds   `json:"pause-on-active"`  // 用户活动时暂停，空闲该秒数后恢复
+	Manager        ManagerConfig `json:"manager"`
+}
+
+// defaultIdleSeconds pause-on-active 为 true 时 XMRig 使用的空闲秒数
+const defaultIdleSeconds = 60
+
+// IdleSeconds XMRig 的 pause-on-active 取值：false 关闭，true 为 60 秒，数字为空闲秒数。
+// 0 表示关闭，序列化时写为 false
+type IdleSeconds int
+
+// MarshalJSON 0 写为 false，其余写为秒数
+func (s IdleSeconds) MarshalJSON() ([]byte, error) {
+	if s <= 0 {
+		return []byte("false"), nil
+	}
+	return json.Marshal(int(s))
+}
+
+// UnmarshalJSON 接受布尔值、数字或 null
+func (s *IdleSeconds) UnmarshalJSON(data []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case nil:
+		*s = 0
+	case bool:
+		*s = 0
+		if v {
+			*s = defaultIdleSeconds
+		}
+	case float64:
+		if v < 0 {
+			return fmt.Errorf("pause-on-active 不能为负数")
+		}
+		*s = IdleSeconds(v)
+	default:
+		return fmt.Errorf("pause-on-active 应为布尔值或秒数")
+	}
+	return nil
+}
+
+// ManagerConfig 管理器扩展配置，XMRig 会忽略该字段
+type ManagerConfig struct {
+	PoolStrategy string           `json:"pool-strategy"` // ordered | lowest-latency | weighted-random | sticky
+	Binary       BinaryConfig     `json:"binary"`
+	Supervisor   SupervisorConfig `json:"supervisor"`
+	History      HistoryConfig    `json:"history"`
+	Failover     FailoverConfig   `json:"failover"`
+	Governor     GovernorConfig   `json:"governor"`
+	Logs         LogsConfig       `json:"logs"`
+}
+
+// BinaryConfig 矿工可执行文件来源配置
+type BinaryConfig struct {
+	Provider string `json:"provider"` // embedded | path | dir
+	Path     string `json:"path"`     // provider 为 path 时使用
+	Dir      string `json:"dir"`      // provider 为 dir 时使用
+	SHA256   string `json:"sha256"`   // 用户提供程序的期望摘要
 }
 
 // APIConfig API配置
@@ -46,6 +113,7 @@ type PoolConfig struct {
 	Nicehash bool    `json:"nicehash"`
 	Enabled  bool    `json:"enabled"`
 	TLS      bool    `json:"tls"`
+	Weight   int     `json:"weight,omitempty"` // 加权随机策略下的权重，默认 1
 }
 
 // RandomXConfig RandomX算法配置
@@ -57,23 +125,166 @@ type RandomXConfig struct {
 	NUMA       bool   `json:"numa"`
 }
 
+// SupervisorConfig 异常退出自动重启配置，数值为 0 时使用默认值
+type SupervisorConfig struct {
+	Disabled       bool `json:"disabled"`
+	MaxRestarts    int  `json:"max-restarts"`    // 窗口期内最多重启次数
+	WindowSeconds  int  `json:"window-seconds"`  // 统计窗口（秒）
+	InitialBackoff int  `json:"initial-backoff"` // 首次重启等待（秒）
+	MaxBackoff     int  `json:"max-backoff"`     // 最长重启等待（秒）
+}
+
+// HistoryConfig 算力历史采样配置，数值为 0 时使用默认值
+type HistoryConfig struct {
+	Disabled        bool `json:"disabled"`
+	IntervalSeconds int  `json:"interval-seconds"` // 采样间隔（秒）
+	RetentionDays   int  `json:"retention-days"`   // 保留天数
+}
+
+// FailoverConfig 运行中矿池故障切换配置，数值为 0 时使用默认值
+type FailoverConfig struct {
+	Disabled             bool    `json:"disabled"`
+	CheckIntervalSeconds int     `json:"check-interval-seconds"` // 检查间隔（秒）
+	DisconnectedSeconds  int     `json:"disconnected-seconds"`   // 断开超过该时长后切换（秒）
+	MaxRejectRatio       float64 `json:"max-reject-ratio"`       // 拒绝率超过该值后切换，0~1
+	MinShares            int     `json:"min-shares"`             // 计算拒绝率所需的最少份额
+	Method               string  `json:"method"`                 // auto | api | restart
+}
+
+// LogsConfig 矿工输出日志文件配置，数值为 0 时使用默认值
+type LogsConfig struct {
+	Disabled      bool `json:"disabled"`
+	MaxSizeMB     int  `json:"max-size-mb"`    // 单个文件超过该大小后轮转（MB）
+	MaxAgeHours   int  `json:"max-age-hours"`  // 单个文件写入超过该时长后轮转（小时）
+	RetentionDays int  `json:"retention-days"` // 保留天数
+	MaxFiles      int  `json:"max-files"`      // 最多保留的文件数
+}
+
+// GovernorConfig 按其他程序的 CPU 负载暂停挖矿或降低线程，需要显式开启，数值为 0 时使用默认值
+type GovernorConfig struct {
+	Enabled            bool    `json:"enabled"`
+	Mode               string  `json:"mode"`                 // pause | threads
+	HighLoad           float64 `json:"high-load"`            // 其他程序 CPU 占用不低于该百分比视为繁忙
+	LowLoad            float64 `json:"low-load"`             // 不高于该百分比视为空闲
+	SampleSeconds      int     `json:"sample-seconds"`       // 采样间隔（秒）
+	HighSamples        int     `json:"high-samples"`         // 连续繁忙次数达到后让出 CPU
+	LowSamples         int     `json:"low-samples"`          // 连续空闲次数达到后恢复
+	ReducedThreadsHint int     `json:"reduced-threads-hint"` // threads 模式下让出 CPU 时的线程比例
+}
+
+// GovernorStatus 负载调节当前状态
+type GovernorStatus struct {
+	Enabled   bool    `json:"enabled"`
+	Supported bool    `json:"supported"` // 当前系统能否采样 CPU 负载
+	State     string  `json:"state"`     // inactive | normal | throttled
+	Mode      string  `json:"mode"`
+	Load      float64 `json:"load"` // 最近一次采样的其他程序 CPU 占用百分比
+	Error     string  `json:"error,omitempty"`
+}
+
+// RestartRecord 一次异常退出及其处理结果
+type RestartRecord struct {
+	Time     int64  `json:"time"` // Unix 秒
+	ExitCode int    `json:"exitCode"`
+	Reason   string `json:"reason"`
+	Attempt  int    `json:"attempt"`
+	Delay    int    `json:"delay"`  // 重启前等待（秒）
+	Action   string `json:"action"` // scheduled | restarted | failed | gave-up | disabled | cancelled
+	Error    string `json:"error,omitempty"`
+}
+
+// FieldError 字段级校验错误，Field 为 JSON 路径，如 pools[0].url
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
 // MinerStatus 挖矿状态
 type MinerStatus struct {
-	Running   bool    `json:"running"`
-	Hashrate  float64 `json:"hashrate"`
-	Threads   int     `json:"threads"`
-	Uptime    int64   `json:"uptime"`
-	Pool      string  `json:"pool"`
-	Algorithm string  `json:"algorithm"`
-	Connected bool    `json:"connected"`
+	Running         bool    `json:"running"`
+	Hashrate        float64 `json:"hashrate"`
+	Hashrate10s     float64 `json:"hashrate10s"`
+	Hashrate60s     float64 `json:"hashrate60s"`
+	Hashrate15m     float64 `json:"hashrate15m"`
+	HashrateHighest float64 `json:"hashrateHighest"`
+	Threads         int     `json:"threads"`
+	Uptime          int64   `json:"uptime"`
+	Pool            string  `json:"pool"`
+	PoolPing        int64   `json:"poolPing"` // 毫秒
+	Algorithm       string  `json:"algorithm"`
+	Difficulty      uint64  `json:"difficulty"`
+	SharesAccepted  uint64  `json:"sharesAccepted"`
+	SharesRejected  uint64  `json:"sharesRejected"`
+	SharesInvalid   uint64  `json:"sharesInvalid"` // 被矿池以无效/低难度拒绝的份额，包含在 SharesRejected 中
+	Connected       bool    `json:"connected"`
+}
+
+// HistorySample 算力历史采样
+type HistorySample struct {
+	Time           int64   `json:"time"` // Unix 秒
+	Running        bool    `json:"running"`
+	Hashrate       float64 `json:"hashrate"`
+	Hashrate60s    float64 `json:"hashrate60s"`
+	SharesAccepted uint64  `json:"sharesAccepted"`
+	SharesRejected uint64  `json:"sharesRejected"`
+	Threads        int     `json:"threads"`
+	Pool           string  `json:"pool"`
+	Connected      bool    `json:"connected"`
+}
+
+// PoolSelection 一次矿池选择的结果
+type PoolSelection struct {
+	Strategy string      `json:"strategy"`
+	Chosen   string      `json:"chosen"`
+	Time     int64       `json:"time"` // Unix 秒
+	Probes   []PoolProbe `json:"probes"`
+}
+
+// PoolProbe 单个矿池的探测结果
+type PoolProbe struct {
+	URL       string  `json:"url"`
+	OK        bool    `json:"ok"`
+	Class     string  `json:"class"`
+	Error     string  `json:"error,omitempty"`
+	ConnectMs float64 `json:"connectMs"`
+	LatencyMs float64 `json:"latencyMs"`
 }
 
 // SystemInfo 系统信息
 type SystemInfo struct {
-	OS           string `json:"os"`
-	Arch         string `json:"arch"`
-	CPUModel     string `json:"cpuModel"`
-	CPUCores     int    `json:"cpuCores"`
-	TotalMemory  uint64 `json:"totalMemory"`
-	XMRigVersion string `json:"xmrigVersion"`
+	OS               string        `json:"os"`
+	Arch             string        `json:"arch"`
+	CPUModel         string        `json:"cpuModel"`
+	CPUCores         int           `json:"cpuCores"`         // 逻辑核心数
+	CPUPhysicalCores int           `json:"cpuPhysicalCores"` // 物理核心数，无法获取时为 0
+	CPUCaches        []CPUCache    `json:"cpuCaches"`
+	NUMANodes        int           `json:"numaNodes"`
+	AES              bool          `json:"aes"`         // 支持 AES 指令，RandomX 依赖其加速
+	AVX2             bool          `json:"avx2"`        // 支持 AVX2 指令
+	TotalMemory      uint64        `json:"totalMemory"` // 字节
+	FreeMemory       uint64        `json:"freeMemory"`  // 可用内存，字节
+	HugePages        HugePagesInfo `json:"hugePages"`
+	XMRigVersion     string        `json:"xmrigVersion"`
+	XMRigPath        string        `json:"xmrigPath"`
+	XMRigSource      string        `json:"xmrigSource"`
+	Error            string        `json:"error,omitempty"` // 部分硬件信息获取失败的原因
+}
+
+// CPUCache CPU 缓存，同一级别与类型的多个实例合并为一项
+type CPUCache struct {
+	Level int    `json:"level"`
+	Type  string `json:"type"` // data | instruction | unified
+	Size  uint64 `json:"size"` // 单个实例的大小，字节
+	Count int    `json:"count"`
+}
+
+// HugePagesInfo 大页内存状态。Linux 为系统预留的大页，
+// Windows 为大页大小与当前用户是否拥有锁定内存页权限
+type HugePagesInfo struct {
+	Supported bool   `json:"supported"`
+	Available bool   `json:"available"` // XMRig 能否使用大页：Linux 有空闲大页，Windows 拥有权限
+	PageSize  uint64 `json:"pageSize"`  // 字节
+	Total     uint64 `json:"total"`     // 预留的页数，仅 Linux
+	Free      uint64 `json:"free"`      // 空闲的页数，仅 Linux
+	OneGB     bool   `json:"oneGB"`     // 系统支持 1GB 大页，仅 Linux
 }
@@ -0,0 +1,88 @@
+package models
+
+// 日志级别
+const (
+	LogInfo    = "info"
+	LogWarning = "warning"
+	LogError   = "error"
+)
+
+// LogRecord 解析后的一行日志
+type LogRecord struct {
+	Seq     uint64    `json:"seq"`     // 递增序号，从 1 开始，清空日志与重启后继续递增
+	Time    int64     `json:"time"`    // Unix 毫秒，取 XMRig 行首时间戳，没有时为接收时间
+	Source  string    `json:"source"`  // stdout | stderr | manager
+	Tag     string    `json:"tag"`     // XMRig 模块标签，如 net、cpu、randomx、miner；管理器日志为 manager
+	Level   string    `json:"level"`   // info | warning | error
+	Message string    `json:"message"` // 去掉时间戳与标签后的内容
+	Line    string    `json:"line"`    // 去除颜色代码后的整行
+	Share   *ShareLog `json:"share,omitempty"`
+	Job     *JobLog   `json:"job,omitempty"`
+	Speed   *SpeedLog `json:"speed,omitempty"`
+}
+
+// ShareLog 份额提交结果，对应 accepted/rejected 行
+type ShareLog struct {
+	Accepted  bool   `json:"accepted"`
+	Total     uint64 `json:"total"`    // 累计接受数
+	Rejected  uint64 `json:"rejected"` // 累计拒绝数
+	Diff      uint64 `json:"diff"`
+	LatencyMs int64  `json:"latencyMs"`
+	Reason    string `json:"reason,omitempty"` // 矿池给出的拒绝原因
+}
+
+// JobLog 矿池下发的新任务，对应 new job 行
+type JobLog struct {
+	Pool   string `json:"pool"`
+	Diff   uint64 `json:"diff"`
+	Algo   string `json:"algo"`
+	Height uint64 `json:"height"`
+}
+
+// SpeedLog 算力报告，对应 speed 行，未统计的窗口为 0
+type SpeedLog struct {
+	Hashrate10s float64 `json:"hashrate10s"`
+	Hashrate60s float64 `json:"hashrate60s"`
+	Hashrate15m float64 `json:"hashrate15m"`
+	Highest     float64 `json:"highest"`
+}
+
+// LogSession 一次启动对应的日志文件
+type LogSession struct {
+	ID       string `json:"id"`       // 启动时间，如 20250101-120000-000
+	Start    int64  `json:"start"`    // Unix 秒
+	Modified int64  `json:"modified"` // 最后写入时间，Unix 秒
+	Size     int64  `json:"size"`     // 所有分段的字节数（压缩后）
+	Files    int    `json:"files"`
+	Active   bool   `json:"active"` // 是否仍在写入
+}
+
+// LogQuery 日志查询条件，各列表为空时不按该项过滤
+type LogQuery struct {
+	Levels  []string `json:"levels,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+	Sources []string `json:"sources,omitempty"` // stdout | stderr | manager
+	From    int64    `json:"from,omitempty"`    // 起始时间，Unix 毫秒，包含
+	To      int64    `json:"to,omitempty"`      // 结束时间，Unix 毫秒，不包含
+	Text    string   `json:"text,omitempty"`    // 在整行中查找，不区分大小写
+	Regex   bool     `json:"regex,omitempty"`   // Text 按正则表达式匹配
+	// Before 向前翻页的游标，只返回序号小于它的日志，取上一页结果的 Cursor
+	Before uint64 `json:"before,omitempty"`
+	// After 追踪模式，只返回序号大于它的日志，按时间顺序从最早的开始，取上一次结果的 Latest
+	After uint64 `json:"after,omitempty"`
+	Limit int    `json:"limit,omitempty"` // 每页条数，默认 200
+}
+
+// LogPage 日志查询结果，Records 按时间顺序排列
+type LogPage struct {
+	Records []LogRecord `json:"records"`
+	// Cursor 继续向前翻页时作为 Before 传入，没有更早的匹配日志时为 0
+	Cursor uint64 `json:"cursor"`
+	// Latest 追踪新日志时作为 After 传入
+	Latest uint64 `json:"latest"`
+	// More 追踪模式下还有未返回的新日志
+	More bool `json:"more"`
+	// Truncated 追踪模式下 After 之后的部分日志已移出缓冲区，结果与上次之间有缺口，
+	// 缺失的日志只能在日志文件中查看
+	Truncated bool `json:"truncated"`
+}
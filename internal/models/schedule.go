@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// ScheduleConfig 定时挖矿配置，规则按顺序匹配，第一条覆盖当前时间的规则生效
+type ScheduleConfig struct {
+	Enabled       bool           `json:"enabled"`
+	Timezone      string         `json:"timezone"`       // IANA 时区名称，如 Asia/Shanghai，空为本地时区
+	DefaultAction string         `json:"default-action"` // 没有规则匹配时的动作：stop（默认）| mine
+	Rules         []ScheduleRule `json:"rules"`
+}
+
+// ScheduleRule 每周时间窗口规则
+type ScheduleRule struct {
+	Name           string   `json:"name"`
+	Days           []string `json:"days"`                       // mon..sun，空为每天
+	Start          string   `json:"start"`                      // HH:MM
+	End            string   `json:"end"`                        // HH:MM，不大于 start 时跨越午夜，24:00 表示当天结束
+	Action         string   `json:"action"`                     // mine | stop
+	Profile        string   `json:"profile,omitempty"`          // mine 时使用的配置方案，空为当前方案
+	MaxThreadsHint int      `json:"max-threads-hint,omitempty"` // mine 时覆盖线程比例，0 为使用方案设置
+}
+
+// ScheduleStatus 定时挖矿当前状态与下一次切换
+type ScheduleStatus struct {
+	Enabled        bool       `json:"enabled"`
+	Rule           string     `json:"rule"` // 当前匹配的规则，空为默认动作
+	Action         string     `json:"action"`
+	Profile        string     `json:"profile"`
+	MaxThreadsHint int        `json:"maxThreadsHint"`
+	Next           *time.Time `json:"next,omitempty"` // 下一次切换时间，一周内没有切换时为空
+	NextRule       string     `json:"nextRule"`
+	NextAction     string     `json:"nextAction"`
+	NextProfile    string     `json:"nextProfile"`
+	Error          string     `json:"error,omitempty"` // 规则无效时的错误
+}
@@ -0,0 +1,115 @@
+package models
+
+// SettingsVersion 当前设置文件格式版本
+//
+//	1: 单一 miner 设置
+//	2: 多个命名配置方案
+const SettingsVersion = 2
+
+// DefaultProfileName 默认配置方案名称
+const DefaultProfileName = "default"
+
+// Settings 管理器设置文件，只保存用户意图；XMRig 使用的 config.json
+// 在每次启动时由当前配置方案、内置模板与运行时决定渲染生成
+type Settings struct {
+	Version       int       `json:"version"`
+	ActiveProfile string    `json:"active-profile"`
+	Profiles      []Profile `json:"profiles"`
+	// Miner 版本 1 的单一设置，读取时迁移为默认配置方案
+	Miner    *MinerSettings `json:"miner,omitempty"`
+	Manager  ManagerConfig  `json:"manager"`
+	Schedule ScheduleConfig `json:"schedule"`
+	// XMRigOverrides 界面未覆盖的 XMRig 配置项，渲染时原样合并到顶层
+	XMRigOverrides map[string]interface{} `json:"xmrig-overrides,omitempty"`
+}
+
+// Profile 命名配置方案，例如白天半速、夜间全速或备用矿池
+type Profile struct {
+	Name  string        `json:"name"`
+	Miner MinerSettings `json:"miner"`
+}
+
+// ProfileInfo 配置方案列表项
+type ProfileInfo struct {
+	Name   string `json:"name"`
+	Active bool   `json:"active"`
+	Pools  int    `json:"pools"`
+}
+
+// MinerSettings 用户对 XMRig 的设置，矿池顺序即用户填写的顺序
+type MinerSettings struct {
+	API            APIConfig     `json:"api"`
+	HTTP           HTTPConfig    `json:"http"`
+	Autosave       bool          `json:"autosave"`
+	CPU            CPUConfig     `json:"cpu"`
+	Pools          []PoolConfig  `json:"pools"`
+	RandomX        RandomXConfig `json:"randomx"`
+	LogFile        *string       `json:"log-file"`
+	PauseOnBattery bool          `json:"pause-on-battery"`
+	PauseOnActive  IdleSeconds   `json:"pause-on-active"`
+}
+
+// Profile 按名称查找配置方案，不存在时返回 nil
+func (s *Settings) Profile(name string) *Profile {
+	for i := range s.Profiles {
+		if s.Profiles[i].Name == name {
+			return &s.Profiles[i]
+		}
+	}
+	return nil
+}
+
+// Active 返回当前配置方案，active-profile 无效时使用第一个方案
+func (s *Settings) Active() *Profile {
+	if p := s.Profile(s.ActiveProfile); p != nil {
+		return p
+	}
+	if len(s.Profiles) == 0 {
+		s.Profiles = append(s.Profiles, Profile{Name: DefaultProfileName})
+	}
+	s.ActiveProfile = s.Profiles[0].Name
+	return &s.Profiles[0]
+}
+
+// Config 将当前配置方案转换为界面与接口使用的配置结构
+func (s *Settings) Config() *XMRigConfig {
+	return s.ProfileConfig(s.Active())
+}
+
+// ProfileConfig 将指定配置方案转换为界面与接口使用的配置结构
+func (s *Settings) ProfileConfig(p *Profile) *XMRigConfig {
+	m := p.Miner
+	return &XMRigConfig{
+		API:            m.API,
+		HTTP:           m.HTTP,
+		Autosave:       m.Autosave,
+		CPU:            m.CPU,
+		Pools:          append([]PoolConfig(nil), m.Pools...),
+		RandomX:        m.RandomX,
+		LogFile:        m.LogFile,
+		PauseOnBattery: m.PauseOnBattery,
+		PauseOnActive:  m.PauseOnActive,
+		Manager:        s.Manager,
+	}
+}
+
+// Apply 用界面提交的配置更新当前配置方案与管理器设置，XMRigOverrides 保持不变
+func (s *Settings) Apply(cfg *XMRigConfig) {
+	s.Active().Miner = MinerSettingsFrom(cfg)
+	s.Manager = cfg.Manager
+}
+
+// MinerSettingsFrom 从配置结构中提取 XMRig 设置
+func MinerSettingsFrom(cfg *XMRigConfig) MinerSettings {
+	return MinerSettings{
+		API:            cfg.API,
+		HTTP:           cfg.HTTP,
+		Autosave:       cfg.Autosave,
+		CPU:            cfg.CPU,
+		Pools:          append([]PoolConfig(nil), cfg.Pools...),
+		RandomX:        cfg.RandomX,
+		LogFile:        cfg.LogFile,
+		PauseOnBattery: cfg.PauseOnBattery,
+		PauseOnActive:  cfg.PauseOnActive,
+	}
+}
@@ -0,0 +1,225 @@
+// Package schedule 计算定时挖矿规则在任意时刻的生效状态与下一次切换时间。
+// 时间来源通过 Clock 注入，便于在测试中模拟。
+package schedule
+
+import (
+	"fmt"
+	"go-wails/internal/models"
+	"strings"
+	"time"
+
+	// 内置时区数据，Windows 等缺少系统时区库的环境也能解析时区名称
+	_ "time/tzdata"
+)
+
+// 规则动作
+const (
+	ActionMine = "mine"
+	ActionStop = "stop"
+)
+
+// lookahead 计算下一次切换时向后查找的范围，每周规则在一周加一天内必然重复
+const lookahead = 8 * 24 * time.Hour
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// State 某一时刻期望的挖矿状态
+type State struct {
+	Rule           string // 匹配的规则名称，空为默认动作
+	Action         string
+	Profile        string
+	MaxThreadsHint int
+}
+
+// Same 判断两个状态对挖矿的影响是否相同
+func (s State) Same(other State) bool {
+	return s.Action == other.Action && s.Profile == other.Profile && s.MaxThreadsHint == other.MaxThreadsHint
+}
+
+// Schedule 编译后的定时规则
+type Schedule struct {
+	location      *time.Location
+	defaultAction string
+	rules         []rule
+}
+
+type rule struct {
+	state       State
+	days        [7]bool
+	start, end  int // 自当天零点起的分钟数
+	crossesNext bool
+}
+
+// Compile 校验并编译定时配置
+func Compile(cfg models.ScheduleConfig) (*Schedule, error) {
+	s := &Schedule{location: time.Local, defaultAction: ActionStop}
+	if cfg.Timezone != "" {
+		loc, err := time.LoadLocation(cfg.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("无效的时区 %q", cfg.Timezone)
+		}
+		s.location = loc
+	}
+	switch cfg.DefaultAction {
+	case "", ActionStop:
+	case ActionMine:
+		s.defaultAction = ActionMine
+	default:
+		return nil, fmt.Errorf("无效的默认动作 %q，可选值: %s、%s", cfg.DefaultAction, ActionMine, ActionStop)
+	}
+
+	for i, rc := range cfg.Rules {
+		r, err := compileRule(rc)
+		if err != nil {
+			name := rc.Name
+			if name == "" {
+				name = fmt.Sprintf("#%d", i+1)
+			}
+			return nil, fmt.Errorf("规则 %s: %w", name, err)
+		}
+		if r.state.Rule == "" {
+			r.state.Rule = fmt.Sprintf("#%d", i+1)
+		}
+		s.rules = append(s.rules, r)
+	}
+	return s, nil
+}
+
+func compileRule(rc models.ScheduleRule) (rule, error) {
+	r := rule{state: State{
+		Rule:           rc.Name,
+		Action:         rc.Action,
+		Profile:        rc.Profile,
+		MaxThreadsHint: rc.MaxThreadsHint,
+	}}
+	switch rc.Action {
+	case ActionMine:
+		if rc.MaxThreadsHint < 0 || rc.MaxThreadsHint > 100 {
+			return r, fmt.Errorf("线程比例必须在 0-100 之间，0 为使用方案设置")
+		}
+	case ActionStop:
+		r.state.Profile = ""
+		r.state.MaxThreadsHint = 0
+	default:
+		return r, fmt.Errorf("无效的动作 %q，可选值: %s、%s", rc.Action, ActionMine, ActionStop)
+	}
+
+	if len(rc.Days) == 0 {
+		for i := range r.days {
+			r.days[i] = true
+		}
+	}
+	for _, d := range rc.Days {
+		wd, ok := weekdays[strings.ToLower(strings.TrimSpace(d))]
+		if !ok {
+			return r, fmt.Errorf("无效的星期 %q，可选值: mon、tue、wed、thu、fri、sat、sun", d)
+		}
+		r.days[wd] = true
+	}
+
+	var err error
+	if r.start, err = parseClock(rc.Start, false); err != nil {
+		return r, fmt.Errorf("开始时间: %w", err)
+	}
+	if r.end, err = parseClock(rc.End, true); err != nil {
+		return r, fmt.Errorf("结束时间: %w", err)
+	}
+	// 结束时间不晚于开始时间时窗口跨越午夜，相等时为整整一天
+	r.crossesNext = r.end <= r.start
+	return r, nil
+}
+
+// parseClock 解析 HH:MM，allowEndOfDay 为 true 时允许 24:00
+func parseClock(v string, allowEndOfDay bool) (int, error) {
+	var h, m int
+	if _, err := fmt.Sscanf(strings.TrimSpace(v), "%d:%d", &h, &m); err != nil {
+		return 0, fmt.Errorf("时间 %q 格式应为 HH:MM", v)
+	}
+	if allowEndOfDay && h == 24 && m == 0 {
+		return 24 * 60, nil
+	}
+	if h < 0 || h > 23 || m < 0 || m > 59 {
+		return 0, fmt.Errorf("时间 %q 超出范围", v)
+	}
+	return h*60 + m, nil
+}
+
+// Location 返回规则使用的时区
+func (s *Schedule) Location() *time.Location {
+	return s.location
+}
+
+// At 返回 t 时刻期望的挖矿状态
+func (s *Schedule) At(t time.Time) State {
+	t = t.In(s.location)
+	for _, r := range s.rules {
+		// 检查当天开始的窗口与前一天开始、跨越午夜的窗口
+		for offset := 0; offset <= 1; offset++ {
+			start, end, ok := r.window(t, -offset, s.location)
+			if ok && !t.Before(start) && t.Before(end) {
+				return r.state
+			}
+		}
+	}
+	return State{Action: s.defaultAction}
+}
+
+// Next 返回 t 之后第一次状态变化的时间与新状态，一周内没有变化时 ok 为 false
+func (s *Schedule) Next(t time.Time) (next time.Time, state State, ok bool) {
+	current := s.At(t)
+	limit := t.Add(lookahead)
+
+	// 所有规则窗口的边界都是候选切换点，按时间顺序检查
+	var best time.Time
+	for _, r := range s.rules {
+		for offset := -1; offset <= 8; offset++ {
+			start, end, inDay := r.window(t, offset, s.location)
+			if !inDay {
+				continue
+			}
+			for _, b := range []time.Time{start, end} {
+				if b.After(t) && !b.After(limit) && (best.IsZero() || b.Before(best)) && !s.At(b).Same(current) {
+					best = b
+				}
+			}
+		}
+	}
+	if best.IsZero() {
+		return time.Time{}, State{}, false
+	}
+	return best, s.At(best), true
+}
+
+// window 返回相对 t 所在日期偏移 offset 天的那天该规则的时间窗口，当天不适用时 ok 为 false
+func (r rule) window(t time.Time, offset int, loc *time.Location) (start, end time.Time, ok bool) {
+	y, mo, d := t.In(loc).Date()
+	day := time.Date(y, mo, d+offset, 0, 0, 0, 0, loc)
+	if !r.days[day.Weekday()] {
+		return start, end, false
+	}
+	start = time.Date(day.Year(), day.Month(), day.Day(), 0, r.start, 0, 0, loc)
+	endDay := day.Day()
+	if r.crossesNext {
+		endDay++
+	}
+	end = time.Date(day.Year(), day.Month(), endDay, 0, r.end, 0, 0, loc)
+	return start, end, true
+}
+
+// Clock 时间来源
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// SystemClock 使用系统时间
+type SystemClock struct{}
+
+// Now 当前时间
+func (SystemClock) Now() time.Time { return time.Now() }
+
+// After 等待 d 后返回
+func (SystemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
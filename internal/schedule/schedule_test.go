@@ -0,0 +1,332 @@
+package schedule
+
+import (
+	"go-wails/internal/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeClock 手动推进的时钟，After 立即把时间推进 d
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.now = c.now.Add(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+var _ Clock = (*fakeClock)(nil)
+
+func mineRule(name, start, end string, days ...string) models.ScheduleRule {
+	return models.ScheduleRule{Name: name, Days: days, Start: start, End: end, Action: ActionMine}
+}
+
+func utc(month time.Month, day, hour, min int) time.Time {
+	return time.Date(2026, month, day, hour, min, 0, 0, time.UTC)
+}
+
+func mustCompile(t *testing.T, cfg models.ScheduleConfig) *Schedule {
+	t.Helper()
+	s, err := Compile(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     models.ScheduleConfig
+		wantErr string
+	}{
+		{name: "空配置", cfg: models.ScheduleConfig{}},
+		{name: "结束时间 24:00", cfg: models.ScheduleConfig{Rules: []models.ScheduleRule{mineRule("a", "00:00", "24:00")}}},
+		{
+			name: "线程比例 0 使用方案设置",
+			cfg:  models.ScheduleConfig{Rules: []models.ScheduleRule{{Start: "08:00", End: "18:00", Action: ActionMine, MaxThreadsHint: 0}}},
+		},
+		{
+			name: "线程比例 100",
+			cfg:  models.ScheduleConfig{Rules: []models.ScheduleRule{{Start: "08:00", End: "18:00", Action: ActionMine, MaxThreadsHint: 100}}},
+		},
+		{
+			name:    "线程比例超过 100",
+			cfg:     models.ScheduleConfig{Rules: []models.ScheduleRule{{Start: "08:00", End: "18:00", Action: ActionMine, MaxThreadsHint: 101}}},
+			wantErr: "规则 #1: 线程比例必须在 0-100 之间",
+		},
+		{
+			name:    "线程比例为负",
+			cfg:     models.ScheduleConfig{Rules: []models.ScheduleRule{{Start: "08:00", End: "18:00", Action: ActionMine, MaxThreadsHint: -1}}},
+			wantErr: "线程比例必须在 0-100 之间",
+		},
+		{name: "无效时区", cfg: models.ScheduleConfig{Timezone: "Mars/Olympus"}, wantErr: "无效的时区"},
+		{name: "无效默认动作", cfg: models.ScheduleConfig{DefaultAction: "pause"}, wantErr: "无效的默认动作"},
+		{
+			name:    "无效动作",
+			cfg:     models.ScheduleConfig{Rules: []models.ScheduleRule{{Name: "x", Start: "08:00", End: "18:00", Action: "pause"}}},
+			wantErr: "规则 x: 无效的动作",
+		},
+		{
+			name:    "无效星期",
+			cfg:     models.ScheduleConfig{Rules: []models.ScheduleRule{mineRule("x", "08:00", "18:00", "mon", "funday")}},
+			wantErr: "无效的星期",
+		},
+		{
+			name:    "开始时间不能为 24:00",
+			cfg:     models.ScheduleConfig{Rules: []models.ScheduleRule{mineRule("x", "24:00", "06:00")}},
+			wantErr: "开始时间",
+		},
+		{
+			name:    "时间格式错误",
+			cfg:     models.ScheduleConfig{Rules: []models.ScheduleRule{mineRule("x", "08:00", "6pm")}},
+			wantErr: "结束时间",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Compile(tt.cfg)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("得到错误 %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Fatalf("得到错误 %v，期望包含 %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAt(t *testing.T) {
+	type check struct {
+		at     time.Time
+		rule   string
+		action string
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 2026-10-16 为周五
+	tests := []struct {
+		name   string
+		cfg    models.ScheduleConfig
+		checks []check
+	}{
+		{
+			name: "跨越午夜的工作日规则",
+			cfg: models.ScheduleConfig{Timezone: "UTC", Rules: []models.ScheduleRule{
+				mineRule("night", "22:00", "06:00", "mon", "tue", "wed", "thu", "fri"),
+			}},
+			checks: []check{
+				{utc(time.October, 16, 21, 59), "", ActionStop},
+				{utc(time.October, 16, 22, 0), "night", ActionMine},
+				{utc(time.October, 17, 5, 59), "night", ActionMine}, // 周五开始的窗口延续到周六早上
+				{utc(time.October, 17, 6, 0), "", ActionStop},
+				{utc(time.October, 17, 23, 0), "", ActionStop},
+				{utc(time.October, 19, 5, 0), "", ActionStop}, // 周日不在规则内
+			},
+		},
+		{
+			name: "周日跨到下周一",
+			cfg: models.ScheduleConfig{Timezone: "UTC", Rules: []models.ScheduleRule{
+				mineRule("sunday", "20:00", "02:00", "sun"),
+			}},
+			checks: []check{
+				{utc(time.October, 18, 20, 0), "sunday", ActionMine},
+				{utc(time.October, 19, 1, 59), "sunday", ActionMine},
+				{utc(time.October, 19, 2, 0), "", ActionStop},
+				{utc(time.October, 12, 1, 0), "sunday", ActionMine}, // 上周日开始的窗口
+			},
+		},
+		{
+			name: "开始与结束相同为整整一天",
+			cfg: models.ScheduleConfig{Timezone: "UTC", Rules: []models.ScheduleRule{
+				mineRule("day", "09:00", "09:00", "mon"),
+			}},
+			checks: []check{
+				{utc(time.October, 19, 8, 59), "", ActionStop},
+				{utc(time.October, 19, 9, 0), "day", ActionMine},
+				{utc(time.October, 20, 8, 59), "day", ActionMine},
+				{utc(time.October, 20, 9, 0), "", ActionStop},
+			},
+		},
+		{
+			name: "第一条匹配的规则生效",
+			cfg: models.ScheduleConfig{Timezone: "UTC", DefaultAction: ActionMine, Rules: []models.ScheduleRule{
+				{Name: "lunch", Start: "12:00", End: "13:00", Action: ActionStop},
+				mineRule("all", "00:00", "24:00", "sat"),
+			}},
+			checks: []check{
+				{utc(time.October, 17, 12, 30), "lunch", ActionStop},
+				{utc(time.October, 17, 13, 0), "all", ActionMine},
+				{utc(time.October, 17, 23, 59), "all", ActionMine},
+				{utc(time.October, 18, 0, 0), "", ActionMine}, // 默认动作
+			},
+		},
+		{
+			// 2026-03-08 02:00 EST 跳到 03:00 EDT，02:30 不存在
+			name: "夏令时开始",
+			cfg: models.ScheduleConfig{Timezone: "America/New_York", Rules: []models.ScheduleRule{
+				mineRule("gap", "02:30", "05:00"),
+			}},
+			checks: []check{
+				{time.Date(2026, time.March, 8, 3, 30, 0, 0, newYork), "gap", ActionMine},
+				{time.Date(2026, time.March, 8, 4, 59, 0, 0, newYork), "gap", ActionMine},
+				{time.Date(2026, time.March, 8, 5, 0, 0, 0, newYork), "", ActionStop},
+			},
+		},
+		{
+			// 2026-11-01 02:00 EDT 回到 01:00 EST，01:00-02:00 出现两次
+			name: "夏令时结束",
+			cfg: models.ScheduleConfig{Timezone: "America/New_York", Rules: []models.ScheduleRule{
+				mineRule("repeat", "01:00", "02:00"),
+			}},
+			checks: []check{
+				{utc(time.November, 1, 5, 30), "repeat", ActionMine}, // 第一次 01:30 EDT
+				{utc(time.November, 1, 6, 30), "repeat", ActionMine}, // 第二次 01:30 EST
+				{utc(time.November, 1, 7, 0), "", ActionStop},        // 02:00 EST
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := mustCompile(t, tt.cfg)
+			for i, c := range tt.checks {
+				got := s.At(c.at)
+				if got.Rule != c.rule || got.Action != c.action {
+					t.Errorf("第 %d 项 %s: 得到 %s/%s，期望 %s/%s", i, c.at, got.Rule, got.Action, c.rule, c.action)
+				}
+			}
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	type transition struct {
+		at      time.Time
+		action  string
+		profile string
+	}
+	tests := []struct {
+		name  string
+		cfg   models.ScheduleConfig
+		start time.Time
+		want  []transition
+	}{
+		{
+			name: "跨越午夜的工作日规则",
+			cfg: models.ScheduleConfig{Timezone: "UTC", Rules: []models.ScheduleRule{
+				mineRule("night", "22:00", "06:00", "mon", "tue", "wed", "thu", "fri"),
+			}},
+			start: utc(time.October, 16, 12, 0),
+			want: []transition{
+				{at: utc(time.October, 16, 22, 0), action: ActionMine},
+				{at: utc(time.October, 17, 6, 0), action: ActionStop},
+				{at: utc(time.October, 19, 22, 0), action: ActionMine}, // 跳过周末
+				{at: utc(time.October, 20, 6, 0), action: ActionStop},
+			},
+		},
+		{
+			name: "每周一次的规则跨周",
+			cfg: models.ScheduleConfig{Timezone: "UTC", Rules: []models.ScheduleRule{
+				mineRule("sunday", "20:00", "02:00", "sun"),
+			}},
+			start: utc(time.October, 17, 12, 0),
+			want: []transition{
+				{at: utc(time.October, 18, 20, 0), action: ActionMine},
+				{at: utc(time.October, 19, 2, 0), action: ActionStop},
+				{at: utc(time.October, 25, 20, 0), action: ActionMine},
+			},
+		},
+		{
+			name: "相邻规则切换配置方案",
+			cfg: models.ScheduleConfig{Timezone: "UTC", Rules: []models.ScheduleRule{
+				{Name: "day", Start: "08:00", End: "12:00", Action: ActionMine, Profile: "low"},
+				{Name: "afternoon", Start: "12:00", End: "18:00", Action: ActionMine, Profile: "high"},
+			}},
+			start: utc(time.October, 17, 0, 0),
+			want: []transition{
+				{at: utc(time.October, 17, 8, 0), action: ActionMine, profile: "low"},
+				{at: utc(time.October, 17, 12, 0), action: ActionMine, profile: "high"},
+				{at: utc(time.October, 17, 18, 0), action: ActionStop},
+			},
+		},
+		{
+			// 2026-03-08 01:00 EST 开始、04:00 EDT 结束，窗口只有两小时
+			name: "夏令时开始",
+			cfg: models.ScheduleConfig{Timezone: "America/New_York", Rules: []models.ScheduleRule{
+				mineRule("early", "01:00", "04:00"),
+			}},
+			start: utc(time.March, 7, 17, 0),
+			want: []transition{
+				{at: utc(time.March, 8, 6, 0), action: ActionMine},
+				{at: utc(time.March, 8, 8, 0), action: ActionStop},
+				{at: utc(time.March, 9, 5, 0), action: ActionMine},
+			},
+		},
+		{
+			// 2026-11-01 01:00 EDT 开始、03:00 EST 结束，窗口有三小时
+			name: "夏令时结束",
+			cfg: models.ScheduleConfig{Timezone: "America/New_York", Rules: []models.ScheduleRule{
+				mineRule("early", "01:00", "03:00"),
+			}},
+			start: utc(time.October, 31, 16, 0),
+			want: []transition{
+				{at: utc(time.November, 1, 5, 0), action: ActionMine},
+				{at: utc(time.November, 1, 8, 0), action: ActionStop},
+				{at: utc(time.November, 2, 6, 0), action: ActionMine},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := mustCompile(t, tt.cfg)
+			clock := &fakeClock{now: tt.start}
+			// 与调度器相同：计算下一次切换，等待到该时间后再计算
+			for i, want := range tt.want {
+				next, state, ok := s.Next(clock.Now())
+				if !ok {
+					t.Fatalf("第 %d 次切换: 没有找到", i)
+				}
+				<-clock.After(next.Sub(clock.Now()))
+				if !clock.Now().Equal(want.at) || state.Action != want.action || state.Profile != want.profile {
+					t.Fatalf("第 %d 次切换: 得到 %s %s/%s，期望 %s %s/%s",
+						i, clock.Now().UTC(), state.Action, state.Profile, want.at, want.action, want.profile)
+				}
+				if got := s.At(clock.Now()); !got.Same(state) {
+					t.Fatalf("第 %d 次切换: At 得到 %+v，与 Next 返回的 %+v 不一致", i, got, state)
+				}
+			}
+		})
+	}
+}
+
+func TestNextWithoutChange(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  models.ScheduleConfig
+	}{
+		{name: "没有规则", cfg: models.ScheduleConfig{Timezone: "UTC"}},
+		{name: "每天全天挖矿", cfg: models.ScheduleConfig{Timezone: "UTC", Rules: []models.ScheduleRule{mineRule("all", "00:00", "24:00")}}},
+		{
+			name: "规则动作与默认动作相同",
+			cfg: models.ScheduleConfig{Timezone: "UTC", Rules: []models.ScheduleRule{
+				{Name: "off", Start: "09:00", End: "17:00", Action: ActionStop},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := mustCompile(t, tt.cfg)
+			if next, state, ok := s.Next(utc(time.October, 17, 12, 0)); ok {
+				t.Fatalf("得到切换 %s %+v，期望没有切换", next, state)
+			}
+		})
+	}
+}
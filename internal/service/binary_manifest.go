@@ -0,0 +1,76 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// BinaryManifest 可执行文件路径到期望 SHA-256 摘要（十六进制）的映射
+type BinaryManifest map[string]string
+
+// BinaryVerificationError 可执行文件校验失败
+type BinaryVerificationError struct {
+	Path     string
+	Expected string
+	Actual   string
+}
+
+func (e *BinaryVerificationError) Error() string {
+	if e.Expected == "" {
+		return fmt.Sprintf("矿工程序 %s 未配置 SHA-256 校验值（当前摘要 %s），确认文件可信后填入 manager.binary.sha256", e.Path, e.Actual)
+	}
+	return fmt.Sprintf("矿工程序 %s 校验失败: 期望 SHA-256 %s，实际 %s", e.Path, e.Expected, e.Actual)
+}
+
+// Verify 逐个校验清单中的文件，任一文件不匹配即返回错误
+func (m BinaryManifest) Verify() error {
+	paths := make([]string, 0, len(m))
+	for path := range m {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		actual, err := fileSHA256(path)
+		if err != nil {
+			return fmt.Errorf("计算 %s 的摘要失败: %w", path, err)
+		}
+		expected := normalizeDigest(m[path])
+		if expected == "" || expected != actual {
+			return &BinaryVerificationError{Path: path, Expected: expected, Actual: actual}
+		}
+	}
+	return nil
+}
+
+// fileSHA256 计算文件的 SHA-256 摘要
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// bytesSHA256 计算数据的 SHA-256 摘要
+func bytesSHA256(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeDigest 统一摘要格式，兼容 "sha256:" 前缀与大写
+func normalizeDigest(digest string) string {
+	digest = strings.TrimSpace(strings.ToLower(digest))
+	return strings.TrimPrefix(digest, "sha256:")
+}
@@ -0,0 +1,266 @@
+package service
+
+import (
+	"fmt"
+	"go-wails/internal/models"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// 矿工可执行文件来源
+const (
+	BinaryProviderEmbedded = "embedded"
+	BinaryProviderPath     = "path"
+	BinaryProviderDir      = "dir"
+)
+
+// dirScanMaxDepth 目录扫描的最大深度
+const dirScanMaxDepth = 3
+
+// MinerBinaryProvider 矿工可执行文件提供者
+type MinerBinaryProvider interface {
+	// Name 提供者名称
+	Name() string
+	// Resolve 返回可直接执行的矿工程序路径
+	Resolve() (string, error)
+	// Manifest 返回最近一次 Resolve 涉及文件的期望摘要
+	Manifest() BinaryManifest
+}
+
+// NewMinerBinaryProvider 根据配置创建可执行文件提供者，notify 用于提示不影响运行的问题，可为 nil
+func NewMinerBinaryProvider(cfg models.BinaryConfig, runtimeDir string, notify func(level, message string)) (MinerBinaryProvider, error) {
+	switch cfg.Provider {
+	case "", BinaryProviderEmbedded:
+		return &embeddedBinaryProvider{runtimeDir: runtimeDir, notify: notify}, nil
+	case BinaryProviderPath:
+		if strings.TrimSpace(cfg.Path) == "" {
+			return nil, fmt.Errorf("未配置矿工程序路径")
+		}
+		return &pathBinaryProvider{path: cfg.Path, sha256: cfg.SHA256}, nil
+	case BinaryProviderDir:
+		if strings.TrimSpace(cfg.Dir) == "" {
+			return nil, fmt.Errorf("未配置矿工程序目录")
+		}
+		return &dirBinaryProvider{dir: cfg.Dir, sha256: cfg.SHA256}, nil
+	default:
+		return nil, fmt.Errorf("未知的矿工程序来源: %s", cfg.Provider)
+	}
+}
+
+// embeddedBinaryProvider 使用程序内嵌的官方 XMRig
+type embeddedBinaryProvider struct {
+	runtimeDir string
+	notify     func(level, message string)
+	manifest   BinaryManifest
+}
+
+func (p *embeddedBinaryProvider) Name() string {
+	return BinaryProviderEmbedded
+}
+
+// embeddedDir 当前平台对应的内嵌目录
+func embeddedDir() string {
+	if runtime.GOOS == "windows" && runtime.GOARCH != "arm64" {
+		return "xmrig-embedded/xmrig-windows-amd64"
+	}
+	return fmt.Sprintf("xmrig-embedded/xmrig-%s-%s", runtime.GOOS, runtime.GOARCH)
+}
+
+func (p *embeddedBinaryProvider) Resolve() (string, error) {
+	if err := ensurePrivateDir(p.runtimeDir); err != nil {
+		return "", err
+	}
+
+	dir := embeddedDir()
+	manifest := BinaryManifest{}
+
+	// 提取 XMRig 可执行文件
+	xmrigPath := filepath.Join(p.runtimeDir, xmrigBinaryName)
+	digest, err := extractEmbeddedFile(dir+"/"+xmrigBinaryName, xmrigPath)
+	if err != nil {
+		return "", err
+	}
+	manifest[xmrigPath] = digest
+
+	// 提取附加文件 (如 Windows 下的 WinRing0x64.sys 驱动)
+	for _, name := range embeddedExtraFiles {
+		destPath := filepath.Join(p.runtimeDir, name)
+		digest, err := extractEmbeddedFile(dir+"/"+name, destPath)
+		if err != nil {
+			// 附加文件不是必需的，提示后继续
+			if p.notify != nil {
+				p.notify(models.LogWarning, fmt.Sprintf("无法提取附加文件: %v", err))
+			}
+			continue
+		}
+		manifest[destPath] = digest
+	}
+
+	p.manifest = manifest
+	return xmrigPath, nil
+}
+
+func (p *embeddedBinaryProvider) Manifest() BinaryManifest {
+	return p.manifest
+}
+
+// extractEmbeddedFile 从嵌入的文件系统中提取文件，返回其 SHA-256 摘要
+func extractEmbeddedFile(embeddedPath, destPath string) (string, error) {
+	// 读取嵌入的文件
+	data, err := embeddedXMRig.ReadFile(embeddedPath)
+	if err != nil {
+		return "", fmt.Errorf("读取嵌入文件失败 %s: %w", embeddedPath, err)
+	}
+	digest := bytesSHA256(data)
+
+	// 如果文件已存在且摘要一致，跳过提取
+	if actual, err := fileSHA256(destPath); err == nil && actual == digest {
+		return digest, nil
+	}
+
+	// 先写入同目录的临时文件，再原子替换目标文件
+	if err := writeFileAtomic(destPath, data, 0755); err != nil {
+		return "", fmt.Errorf("写入文件失败 %s: %w", destPath, err)
+	}
+
+	return digest, nil
+}
+
+// writeFileAtomic 写入临时文件并同步到磁盘后重命名为目标文件
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// pathBinaryProvider 使用用户指定的可执行文件
+type pathBinaryProvider struct {
+	path     string
+	sha256   string
+	resolved string
+}
+
+func (p *pathBinaryProvider) Name() string {
+	return BinaryProviderPath
+}
+
+func (p *pathBinaryProvider) Resolve() (string, error) {
+	absPath, err := filepath.Abs(p.path)
+	if err != nil {
+		return "", fmt.Errorf("解析矿工程序路径失败: %w", err)
+	}
+	if err := checkExecutable(absPath); err != nil {
+		return "", err
+	}
+	p.resolved = absPath
+	return absPath, nil
+}
+
+func (p *pathBinaryProvider) Manifest() BinaryManifest {
+	if p.resolved == "" {
+		return nil
+	}
+	return BinaryManifest{p.resolved: p.sha256}
+}
+
+// dirBinaryProvider 在指定目录中查找矿工程序，取最近修改的一个
+type dirBinaryProvider struct {
+	dir      string
+	sha256   string
+	resolved string
+}
+
+func (p *dirBinaryProvider) Name() string {
+	return BinaryProviderDir
+}
+
+func (p *dirBinaryProvider) Resolve() (string, error) {
+	root, err := filepath.Abs(p.dir)
+	if err != nil {
+		return "", fmt.Errorf("解析矿工程序目录失败: %w", err)
+	}
+
+	var found string
+	var foundInfo fs.FileInfo
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			return nil
+		}
+		if d.IsDir() {
+			rel, _ := filepath.Rel(root, path)
+			if rel != "." && strings.Count(rel, string(filepath.Separator)) >= dirScanMaxDepth-1 {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.EqualFold(d.Name(), xmrigBinaryName) {
+			return nil
+		}
+		if checkExecutable(path) != nil {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		if foundInfo == nil || info.ModTime().After(foundInfo.ModTime()) {
+			found, foundInfo = path, info
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("扫描矿工程序目录失败: %w", err)
+	}
+	if found == "" {
+		return "", fmt.Errorf("目录 %s 中未找到 %s", root, xmrigBinaryName)
+	}
+	p.resolved = found
+	return found, nil
+}
+
+func (p *dirBinaryProvider) Manifest() BinaryManifest {
+	if p.resolved == "" {
+		return nil
+	}
+	return BinaryManifest{p.resolved: p.sha256}
+}
+
+// checkExecutable 检查路径是否为可执行的普通文件
+func checkExecutable(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("矿工程序不存在 %s: %w", path, err)
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("矿工程序不是普通文件: %s", path)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0111 == 0 {
+		return fmt.Errorf("矿工程序没有执行权限: %s", path)
+	}
+	return nil
+}
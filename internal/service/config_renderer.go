@@ -0,0 +1,105 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-wails/internal/models"
+)
+
+// RuntimeOptions 本次启动的运行时决定，只影响生成的 XMRig 配置，不写回用户设置
+type RuntimeOptions struct {
+	// PreferredPool 首选矿池在用户矿池列表中的下标，渲染时移到首位；小于 0 时保持用户顺序
+	PreferredPool int
+	// MaxThreadsHint 覆盖配置方案中的线程比例，0 为不覆盖
+	MaxThreadsHint int
+}
+
+// RenderXMRigConfig 依次合并内置模板、XMRigOverrides、当前配置方案与运行时决定，
+// 生成 XMRig 使用的 config.json；后一层覆盖前一层，对象递归合并，数组整体替换
+func RenderXMRigConfig(template []byte, settings *models.Settings, opts RuntimeOptions) ([]byte, error) {
+	rendered := map[string]interface{}{}
+	if len(template) > 0 {
+		if err := json.Unmarshal(template, &rendered); err != nil {
+			return nil, fmt.Errorf("解析配置模板失败: %w", err)
+		}
+	}
+	if settings.XMRigOverrides != nil {
+		rendered = mergeJSON(rendered, settings.XMRigOverrides)
+	}
+
+	miner := settings.Active().Miner
+	miner.Pools = renderPools(miner.Pools, opts.PreferredPool)
+	if opts.MaxThreadsHint > 0 {
+		miner.CPU.MaxThreadsHint = opts.MaxThreadsHint
+	}
+	layer, err := toJSONMap(miner)
+	if err != nil {
+		return nil, fmt.Errorf("序列化用户设置失败: %w", err)
+	}
+	rendered = mergeJSON(rendered, layer)
+
+	data, err := json.MarshalIndent(rendered, "", "    ")
+	if err != nil {
+		return nil, fmt.Errorf("序列化XMRig配置失败: %w", err)
+	}
+	return data, nil
+}
+
+// renderPools 返回首选矿池在前的副本，不修改用户的矿池列表
+func renderPools(pools []models.PoolConfig, preferred int) []models.PoolConfig {
+	if preferred <= 0 || preferred >= len(pools) {
+		return append([]models.PoolConfig(nil), pools...)
+	}
+	return movePoolFirst(pools, preferred)
+}
+
+// movePoolFirst 将指定矿池移到首位，其余矿池保持原有顺序
+func movePoolFirst(pools []models.PoolConfig, index int) []models.PoolConfig {
+	result := make([]models.PoolConfig, 0, len(pools))
+	result = append(result, pools[index])
+	for i, p := range pools {
+		if i == index {
+			continue
+		}
+		result = append(result, p)
+	}
+	return result
+}
+
+// toJSONMap 将结构体按 JSON 标签转换为通用 Map
+func toJSONMap(v interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// mergeJSON 递归合并对象：map合并，数组整体替换，原子值覆盖
+func mergeJSON(dst, src map[string]interface{}) map[string]interface{} {
+	if dst == nil {
+		dst = map[string]interface{}{}
+	}
+	for k, v := range src {
+		switch nv := v.(type) {
+		case map[string]interface{}:
+			if dv, ok := dst[k].(map[string]interface{}); ok {
+				dst[k] = mergeJSON(dv, nv)
+			} else {
+				// 用新的对象替换
+				dst[k] = mergeJSON(map[string]interface{}{}, nv)
+			}
+		case []interface{}:
+			// 切片整体替换（如 pools 等）
+			dst[k] = nv
+		default:
+			// 原子类型覆盖，包含 null
+			dst[k] = nv
+		}
+	}
+	return dst
+}
@@ -7,147 +7,324 @@ import (
 	"go-wails/internal/models"
 	"os"
 	"path/filepath"
+	"reflect"
 	"runtime"
+	"sync"
 )
 
 //go:embed xmrig-embedded/*
 var embeddedConfig embed.FS
 
+// HomeEnv 指定数据目录的环境变量，用于便携安装
+const HomeEnv = "XDAG_MINER_HOME"
+
+const (
+	settingsFileName = "settings.json"
+	configFileName   = "config.json"
+)
+
+// settingsKeys 由 MinerSettings 与 ManagerConfig 表达的 XMRig 配置顶层键，迁移时其余键进入 XMRigOverrides
+var settingsKeys = map[string]bool{
+	"api": true, "http": true, "autosave": true, "cpu": true, "pools": true,
+	"randomx": true, "log-file": true, "pause-on-battery": true, "pause-on-active": true,
+	"manager": true,
+}
+
 // ConfigService 配置服务
+//
+// 用户设置保存在数据目录的 settings.json 中；运行时目录为数据目录下的 runtime 子目录，
+// 只存放提取的 XMRig 与每次启动时渲染的 config.json，仅当前用户可访问，被清理后不影响用户设置
 type ConfigService struct {
-	configPath string
+	dataDir    string
 	runtimeDir string
+	mutex      sync.Mutex
 }
 
-// NewConfigService 创建配置服务
+// NewConfigService 创建配置服务，数据目录取 XDAG_MINER_HOME，未设置时使用系统配置目录
 func NewConfigService() *ConfigService {
-	runtimeDir := filepath.Join(os.TempDir(), "xmrig-runtime")
+	return NewConfigServiceAt(os.Getenv(HomeEnv))
+}
+
+// NewConfigServiceAt 使用指定的数据目录创建配置服务，dataDir 为空时使用系统配置目录
+func NewConfigServiceAt(dataDir string) *ConfigService {
+	if dataDir == "" {
+		if dir, err := os.UserConfigDir(); err == nil {
+			dataDir = filepath.Join(dir, "xdag-miner")
+		} else {
+			dataDir = legacyRuntimeDir()
+		}
+	}
+	if abs, err := filepath.Abs(dataDir); err == nil {
+		dataDir = abs
+	}
 	return &ConfigService{
-		runtimeDir: runtimeDir,
+		dataDir:    dataDir,
+		runtimeDir: filepath.Join(dataDir, "runtime"),
 	}
 }
 
-// GetConfigPath 获取配置文件路径
-func (s *ConfigService) GetConfigPath() string {
-	os.MkdirAll(s.runtimeDir, 0755)
-	return filepath.Join(s.runtimeDir, "config.json")
+// legacyRuntimeDir 旧版本在系统临时目录中使用的共享运行时目录，只用于迁移旧配置
+func legacyRuntimeDir() string {
+	return filepath.Join(os.TempDir(), "xmrig-runtime")
 }
 
-// ensureConfigExists 确保配置文件存在
-func (s *ConfigService) ensureConfigExists() error {
-	configPath := s.GetConfigPath()
-
-	if _, err := os.Stat(configPath); err == nil {
-		return nil
+// ensurePrivateDir 创建仅当前用户可访问的目录。目录已存在时必须是当前用户所有的真实目录，
+// 否则拒绝使用，防止其他用户在校验与执行之间替换其中的矿工程序或配置
+func ensurePrivateDir(dir string) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("创建运行时目录失败: %w", err)
 	}
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return fmt.Errorf("检查运行时目录失败: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("运行时目录 %s 不是目录，拒绝使用", dir)
+	}
+	if !ownedByCurrentUser(info) {
+		return fmt.Errorf("运行时目录 %s 不属于当前用户，拒绝使用", dir)
+	}
+	if info.Mode().Perm()&0077 != 0 {
+		if err := os.Chmod(dir, 0700); err != nil {
+			return fmt.Errorf("修改运行时目录权限失败: %w", err)
+		}
+	}
+	return nil
+}
 
-	arch := runtime.GOARCH
-	var embeddedPath string
-	if arch == "arm64" {
-		embeddedPath = "xmrig-embedded/xmrig-windows-arm64/config.json"
-	} else {
-		embeddedPath = "xmrig-embedded/xmrig-windows-amd64/config.json"
+// GetRuntimeDir 获取运行时目录
+func (s *ConfigService) GetRuntimeDir() string {
+	return s.runtimeDir
+}
+
+// GetDataDir 获取持久化数据目录（用户设置、历史记录等）
+func (s *ConfigService) GetDataDir() string {
+	return s.dataDir
+}
+
+// GetSettingsPath 获取用户设置文件路径
+func (s *ConfigService) GetSettingsPath() string {
+	os.MkdirAll(s.dataDir, 0755)
+	return filepath.Join(s.dataDir, settingsFileName)
+}
+
+// GetRuntimeConfigPath 获取渲染给 XMRig 的配置文件路径
+func (s *ConfigService) GetRuntimeConfigPath() string {
+	return filepath.Join(s.runtimeDir, configFileName)
+}
+
+// LoadSettings 读取用户设置，首次运行时从旧配置迁移或按内置模板创建
+func (s *ConfigService) LoadSettings() (*models.Settings, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.loadSettingsLocked()
+}
+
+// SaveSettings 保存用户设置
+func (s *ConfigService) SaveSettings(settings *models.Settings) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.saveSettingsLocked(settings)
+}
+
+func (s *ConfigService) loadSettingsLocked() (*models.Settings, error) {
+	if err := s.ensureSettingsExist(); err != nil {
+		return nil, err
 	}
 
-	data, err := embeddedConfig.ReadFile(embeddedPath)
+	data, err := os.ReadFile(s.GetSettingsPath())
 	if err != nil {
-		defaultConfig := s.GetDefaultConfig()
-		return s.SaveConfig(defaultConfig)
+		return nil, fmt.Errorf("读取设置文件失败: %w", err)
 	}
 
-	if err := os.WriteFile(configPath, data, 0644); err != nil {
-		return fmt.Errorf("创建配置文件失败: %w", err)
+	var settings models.Settings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("解析设置文件失败: %w", err)
+	}
+	upgraded := settings.Version < models.SettingsVersion
+	if upgraded {
+		upgradeSettings(&settings)
 	}
+	if liftOverrides(&settings) || upgraded {
+		if err := s.saveSettingsLocked(&settings); err != nil {
+			return nil, err
+		}
+	}
+	return &settings, nil
+}
 
-	return nil
+// liftOverrides 将 XMRigOverrides 中后来由配置方案表达的键移入每个配置方案，
+// 否则渲染时会被方案中的默认值覆盖；返回是否有改动
+func liftOverrides(settings *models.Settings) bool {
+	changed := false
+	for key := range settings.XMRigOverrides {
+		if !settingsKeys[key] {
+			continue
+		}
+		data, err := json.Marshal(map[string]interface{}{key: settings.XMRigOverrides[key]})
+		if err != nil {
+			continue
+		}
+		for i := range settings.Profiles {
+			cfg := settings.ProfileConfig(&settings.Profiles[i])
+			if json.Unmarshal(data, cfg) == nil {
+				settings.Profiles[i].Miner = models.MinerSettingsFrom(cfg)
+			}
+		}
+		delete(settings.XMRigOverrides, key)
+		changed = true
+	}
+	return changed
 }
 
-// LoadConfig 加载配置
-func (s *ConfigService) LoadConfig() (*models.XMRigConfig, error) {
-	if err := s.ensureConfigExists(); err != nil {
-		return nil, err
+// upgradeSettings 将旧版本设置升级到当前版本
+func upgradeSettings(settings *models.Settings) {
+	// 版本 1 的单一设置成为默认配置方案
+	if settings.Miner != nil && len(settings.Profiles) == 0 {
+		settings.Profiles = []models.Profile{{Name: models.DefaultProfileName, Miner: *settings.Miner}}
+		settings.ActiveProfile = models.DefaultProfileName
 	}
+	settings.Miner = nil
+	settings.Active()
+}
 
-	configPath := s.GetConfigPath()
-	data, err := os.ReadFile(configPath)
+func (s *ConfigService) saveSettingsLocked(settings *models.Settings) error {
+	settings.Version = models.SettingsVersion
+	data, err := json.MarshalIndent(settings, "", "    ")
 	if err != nil {
-		return nil, fmt.Errorf("读取配置文件失败: %w", err)
+		return fmt.Errorf("序列化设置失败: %w", err)
+	}
+	if err := writeFileAtomic(s.GetSettingsPath(), data, 0644); err != nil {
+		return fmt.Errorf("保存设置文件失败: %w", err)
+	}
+	return nil
+}
+
+// ensureSettingsExist 确保设置文件存在，依次尝试迁移旧配置、使用内置模板与默认配置
+func (s *ConfigService) ensureSettingsExist() error {
+	if _, err := os.Stat(s.GetSettingsPath()); err == nil {
+		return nil
 	}
 
-	var config models.XMRigConfig
-	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("解析配置文件失败: %w", err)
+	if settings, ok := s.migrateLegacyConfig(); ok {
+		return s.saveSettingsLocked(settings)
 	}
 
-	return &config, nil
+	settings := &models.Settings{}
+	if cfg, _, err := parseXMRigConfig(s.configTemplate()); err == nil {
+		settings.Apply(cfg)
+	} else {
+		settings.Apply(s.GetDefaultConfig())
+	}
+	return s.saveSettingsLocked(settings)
 }
 
-// SaveConfig 保存配置
-func (s *ConfigService) SaveConfig(config *models.XMRigConfig) error {
-	configPath := s.GetConfigPath()
-
-	// 读取现有配置为通用Map，保留未知字段；若不存在则直接写入新配置
-	existingData, err := os.ReadFile(configPath)
-	var existing map[string]interface{}
-	if err == nil {
-		if err := json.Unmarshal(existingData, &existing); err != nil {
-			return fmt.Errorf("解析现有配置失败: %w", err)
+// migrateLegacyConfig 旧版本直接把 XMRig 的 config.json 当作用户配置，
+// 先后保存在数据目录与临时运行时目录中，转换为设置文件；
+// 与模板不同的未知键保存到 XMRigOverrides。临时目录是共享的，只迁移当前用户自己的文件
+func (s *ConfigService) migrateLegacyConfig() (*models.Settings, bool) {
+	candidates := []string{
+		filepath.Join(s.dataDir, configFileName),
+		filepath.Join(legacyRuntimeDir(), configFileName),
+	}
+	for _, path := range candidates {
+		if info, err := os.Lstat(path); err != nil || !info.Mode().IsRegular() || !ownedByCurrentUser(info) {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
 		}
+		cfg, raw, err := parseXMRigConfig(data)
+		if err != nil {
+			// 旧文件已损坏，忽略
+			continue
+		}
+
+		settings := &models.Settings{}
+		settings.Apply(cfg)
+		_, template, _ := parseXMRigConfig(s.configTemplate())
+		for key, value := range raw {
+			if settingsKeys[key] || reflect.DeepEqual(template[key], value) {
+				continue
+			}
+			if settings.XMRigOverrides == nil {
+				settings.XMRigOverrides = map[string]interface{}{}
+			}
+			settings.XMRigOverrides[key] = value
+		}
+		return settings, true
 	}
+	return nil, false
+}
 
-	// 将更新配置转为Map
-	updateBytes, err := json.Marshal(config)
-	if err != nil {
-		return fmt.Errorf("序列化更新配置失败: %w", err)
+// parseXMRigConfig 解析 XMRig 配置，同时返回结构化配置与通用 Map
+func parseXMRigConfig(data []byte) (*models.XMRigConfig, map[string]interface{}, error) {
+	if len(data) == 0 {
+		return nil, nil, fmt.Errorf("配置为空")
+	}
+	var cfg models.XMRigConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, nil, err
 	}
-	var updates map[string]interface{}
-	if err := json.Unmarshal(updateBytes, &updates); err != nil {
-		return fmt.Errorf("解析更新配置失败: %w", err)
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, nil, err
 	}
+	return &cfg, raw, nil
+}
 
-	// 递归合并：仅覆盖传入的键；保留未知键
-	var finalBytes []byte
-	if existing != nil {
-		merged := mergeJSON(existing, updates)
-		finalBytes, err = json.MarshalIndent(merged, "", "    ")
-	} else {
-		// 无现有配置，直接写入更新配置
-		finalBytes, err = json.MarshalIndent(updates, "", "    ")
+// configTemplate 返回当前架构的内置 XMRig 配置模板，不存在时返回 nil
+func (s *ConfigService) configTemplate() []byte {
+	embeddedPath := "xmrig-embedded/xmrig-windows-amd64/config.json"
+	if runtime.GOARCH == "arm64" {
+		embeddedPath = "xmrig-embedded/xmrig-windows-arm64/config.json"
 	}
+	data, err := embeddedConfig.ReadFile(embeddedPath)
 	if err != nil {
-		return fmt.Errorf("序列化合并后的配置失败: %w", err)
+		return nil
 	}
-	if err := os.WriteFile(configPath, finalBytes, 0644); err != nil {
-		return fmt.Errorf("保存配置文件失败: %w", err)
+	return data
+}
+
+// LoadConfig 加载当前配置方案的配置
+func (s *ConfigService) LoadConfig() (*models.XMRigConfig, error) {
+	settings, err := s.LoadSettings()
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	return settings.Config(), nil
 }
 
-// mergeJSON 递归合并对象：map合并，数组整体替换，原子值覆盖
-func mergeJSON(dst, src map[string]interface{}) map[string]interface{} {
-	if dst == nil {
-		dst = map[string]interface{}{}
+// SaveConfig 保存配置到当前配置方案，只更新用户设置中对应的部分
+func (s *ConfigService) SaveConfig(config *models.XMRigConfig) error {
+	return s.updateSettings(func(settings *models.Settings) error {
+		settings.Apply(config)
+		return nil
+	})
+}
+
+// WriteRuntimeConfig 按设置与本次运行时决定渲染 XMRig 的配置文件，返回文件路径
+func (s *ConfigService) WriteRuntimeConfig(settings *models.Settings, opts RuntimeOptions) (string, error) {
+	data, err := RenderXMRigConfig(s.configTemplate(), settings, opts)
+	if err != nil {
+		return "", err
 	}
-	for k, v := range src {
-		switch nv := v.(type) {
-		case map[string]interface{}:
-			if dv, ok := dst[k].(map[string]interface{}); ok {
-				dst[k] = mergeJSON(dv, nv)
-			} else {
-				// 用新的对象替换
-				dst[k] = mergeJSON(map[string]interface{}{}, nv)
-			}
-		case []interface{}:
-			// 切片整体替换（如 pools 等）
-			dst[k] = nv
-		default:
-			// 原子类型覆盖，包含 null
-			dst[k] = nv
-		}
+	if err := ensurePrivateDir(s.runtimeDir); err != nil {
+		return "", err
+	}
+	// 配置中含有 HTTP API 的 access-token，只允许当前用户读取
+	runtimePath := s.GetRuntimeConfigPath()
+	if err := writeFileAtomic(runtimePath, data, 0600); err != nil {
+		return "", fmt.Errorf("生成运行时配置失败: %w", err)
 	}
-	return dst
+	return runtimePath, nil
 }
 
+// DefaultWalletAddress 默认配置与内置配置中附带的钱包地址（项目作者地址），
+// 用户未替换时收益不会进入自己的钱包
+const DefaultWalletAddress = "NNZabJQEhrQGTPabqABWVK9v3rSsNQ7Sy"
+
 // GetDefaultConfig 获取默认配置
 func (s *ConfigService) GetDefaultConfig() *models.XMRigConfig {
 	pass := "x"
@@ -162,9 +339,10 @@ func (s *ConfigService) GetDefaultConfig() *models.XMRigConfig {
 		Pools: []models.PoolConfig{
 			{
 				URL:     "stratum+ssl://equal.xdagminer.com:13003",
-				User:    "NNZabJQEhrQGTPabqABWVK9v3rSsNQ7Sy",
+				User:    DefaultWalletAddress,
 				Pass:    pass,
 				Enabled: true,
+				TLS:     true,
 			},
 		},
 		RandomX: models.RandomXConfig{
@@ -173,6 +351,11 @@ func (s *ConfigService) GetDefaultConfig() *models.XMRigConfig {
 			Mode:     "auto",
 			NUMA:     true,
 		},
+		Manager: models.ManagerConfig{
+			Binary: models.BinaryConfig{
+				Provider: BinaryProviderEmbedded,
+			},
+		},
 		HTTP: models.HTTPConfig{
 			Enabled:    true,
 			Host:       "127.0.0.1",
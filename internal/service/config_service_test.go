@@ -0,0 +1,70 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestEnsurePrivateDir(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows 使用 ACL 控制访问")
+	}
+	root := t.TempDir()
+
+	created := filepath.Join(root, "created", "runtime")
+	if err := ensurePrivateDir(created); err != nil {
+		t.Fatal(err)
+	}
+	if info, _ := os.Stat(created); info.Mode().Perm() != 0700 {
+		t.Errorf("新建目录权限得到 %o，期望 700", info.Mode().Perm())
+	}
+
+	// 自己的目录权限过宽时收紧
+	wide := filepath.Join(root, "wide")
+	if err := os.Mkdir(wide, 0777); err != nil {
+		t.Fatal(err)
+	}
+	os.Chmod(wide, 0777)
+	if err := ensurePrivateDir(wide); err != nil {
+		t.Fatal(err)
+	}
+	if info, _ := os.Stat(wide); info.Mode().Perm() != 0700 {
+		t.Errorf("收紧后权限得到 %o，期望 700", info.Mode().Perm())
+	}
+
+	// 指向其他目录的符号链接可能被他人替换，拒绝使用
+	link := filepath.Join(root, "link")
+	if err := os.Symlink(created, link); err != nil {
+		t.Fatal(err)
+	}
+	if err := ensurePrivateDir(link); err == nil {
+		t.Error("符号链接目录没有被拒绝")
+	}
+}
+
+func TestWriteRuntimeConfigIsPrivate(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows 使用 ACL 控制访问")
+	}
+	configSvc := NewConfigServiceAt(t.TempDir())
+	settings, err := configSvc.LoadSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	path, err := configSvc.WriteRuntimeConfig(settings, RuntimeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Dir(path) != configSvc.GetRuntimeDir() || filepath.Dir(configSvc.GetRuntimeDir()) != configSvc.GetDataDir() {
+		t.Errorf("运行时配置 %s 不在数据目录下", path)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("运行时配置权限得到 %o，期望 600", info.Mode().Perm())
+	}
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"fmt"
+	"go-wails/internal/models"
+	"go-wails/internal/stratum"
+	"go-wails/internal/xdag"
+	"net"
+	"regexp"
+	"strings"
+)
+
+// RandomX 初始化模式，空值等同 auto
+var randomXModes = []string{"auto", "fast", "light"}
+
+// CPU 优先级取值范围，与 XMRig 一致
+const (
+	minCPUPriority = 0
+	maxCPUPriority = 5
+)
+
+// hostnamePattern 匹配 RFC 1123 主机名
+var hostnamePattern = regexp.MustCompile(`^(?i)[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?(\.[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?)*$`)
+
+// ConfigValidationError 配置校验失败，包含全部字段级错误
+type ConfigValidationError struct {
+	Errors []models.FieldError
+}
+
+func (e *ConfigValidationError) Error() string {
+	parts := make([]string, 0, len(e.Errors))
+	for _, fe := range e.Errors {
+		parts = append(parts, fe.Field+": "+fe.Message)
+	}
+	return "配置校验失败: " + strings.Join(parts, "；")
+}
+
+// ValidateConfig 校验配置，返回字段级错误，无错误时返回空切片
+func ValidateConfig(cfg *models.XMRigConfig) []models.FieldError {
+	v := &configValidator{}
+	if cfg == nil {
+		v.add("", "配置不能为空")
+		return v.errors
+	}
+	v.validatePools(cfg.Pools)
+	v.validateHTTP(cfg.HTTP)
+	v.validateCPU(cfg.CPU)
+	v.validateRandomX(cfg.RandomX)
+	v.validateGovernor(cfg.Manager.Governor, cfg.HTTP)
+	v.validateLogs(cfg.Manager.Logs)
+	return v.errors
+}
+
+// ConfigWarnings 返回不阻止保存但需要提醒用户的问题，例如仍在使用内置的默认钱包地址
+func ConfigWarnings(cfg *models.XMRigConfig) []models.FieldError {
+	v := &configValidator{}
+	if cfg == nil {
+		return v.errors
+	}
+	for i, pool := range cfg.Pools {
+		if !pool.Enabled {
+			continue
+		}
+		u, err := xdag.ParseUser(pool.User)
+		if err == nil && u.Address == DefaultWalletAddress {
+			v.add(fmt.Sprintf("pools[%d].user", i), "仍在使用内置的默认钱包地址，挖矿收益不会进入你的钱包")
+		}
+	}
+	return v.errors
+}
+
+// configValidator 收集字段错误
+type configValidator struct {
+	errors []models.FieldError
+}
+
+func (v *configValidator) add(field, format string, args ...interface{}) {
+	v.errors = append(v.errors, models.FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// validatePools 校验矿池列表、地址格式及其与 tls 开关的一致性、钱包地址
+func (v *configValidator) validatePools(pools []models.PoolConfig) {
+	if len(pools) == 0 {
+		v.add("pools", "至少需要配置一个矿池")
+		return
+	}
+
+	enabled := 0
+	for i, pool := range pools {
+		field := fmt.Sprintf("pools[%d]", i)
+		if pool.Enabled {
+			enabled++
+		}
+		if u, err := stratum.ParseURL(pool.URL); err != nil {
+			v.add(field+".url", "%v", err)
+		} else if err := u.CheckTLS(pool.TLS); err != nil {
+			v.add(field+".tls", "%v", err)
+		}
+		v.validateWallet(field+".user", pool.User)
+	}
+	if enabled == 0 {
+		v.add("pools", "至少需要启用一个矿池")
+	}
+}
+
+// validateWallet 校验矿池用户名中的 XDAG 钱包地址与可选矿机名
+func (v *configValidator) validateWallet(field, user string) {
+	if _, err := xdag.ParseUser(user); err != nil {
+		v.add(field, "%v", err)
+	}
+}
+
+// validateHTTP 校验 HTTP API 监听地址与端口，未启用时不校验
+func (v *configValidator) validateHTTP(http models.HTTPConfig) {
+	if !http.Enabled {
+		return
+	}
+	if http.Port < 1 || http.Port > 65535 {
+		v.add("http.port", "端口必须在 1-65535 之间")
+	}
+	host := strings.TrimSpace(http.Host)
+	if host == "" {
+		v.add("http.host", "监听地址不能为空")
+		return
+	}
+	if net.ParseIP(strings.Trim(host, "[]")) == nil && !hostnamePattern.MatchString(host) {
+		v.add("http.host", "监听地址 %q 不是有效的 IP 或主机名", host)
+	}
+}
+
+// validateCPU 校验线程比例与优先级
+func (v *configValidator) validateCPU(cpu models.CPUConfig) {
+	if cpu.MaxThreadsHint < 1 || cpu.MaxThreadsHint > 100 {
+		v.add("cpu.max-threads-hint", "最大线程比例必须在 1-100 之间")
+	}
+	if cpu.Priority != nil && (*cpu.Priority < minCPUPriority || *cpu.Priority > maxCPUPriority) {
+		v.add("cpu.priority", "优先级必须在 %d-%d 之间", minCPUPriority, maxCPUPriority)
+	}
+}
+
+// validateRandomX 校验 RandomX 模式
+func (v *configValidator) validateRandomX(rx models.RandomXConfig) {
+	if rx.Mode == "" {
+		return
+	}
+	for _, mode := range randomXModes {
+		if rx.Mode == mode {
+			return
+		}
+	}
+	v.add("randomx.mode", "不支持的模式 %q，可选值: %s", rx.Mode, strings.Join(randomXModes, ", "))
+}
+
+// validateGovernor 校验负载调节设置，未开启时不校验
+func (v *configValidator) validateGovernor(g models.GovernorConfig, http models.HTTPConfig) {
+	if !g.Enabled {
+		return
+	}
+	const field = "manager.governor"
+	switch g.Mode {
+	case "", GovernorPause, GovernorThreads:
+	default:
+		v.add(field+".mode", "不支持的模式 %q，可选值: %s, %s", g.Mode, GovernorPause, GovernorThreads)
+	}
+	if g.HighLoad < 0 || g.HighLoad > 100 {
+		v.add(field+".high-load", "繁忙阈值必须在 0-100 之间")
+	}
+	if g.LowLoad < 0 || g.LowLoad > 100 {
+		v.add(field+".low-load", "空闲阈值必须在 0-100 之间")
+	}
+	if high, low := governorThresholds(g); low >= high {
+		v.add(field+".low-load", "空闲阈值必须低于繁忙阈值")
+	}
+	if g.ReducedThreadsHint < 0 || g.ReducedThreadsHint > 100 {
+		v.add(field+".reduced-threads-hint", "线程比例必须在 0-100 之间，0 为使用默认值 %d%%", defaultGovernorReducedThread)
+	}
+	if !http.Enabled || http.Restricted {
+		v.add(field+".enabled", "负载调节需要启用 HTTP API 并关闭 restricted")
+	}
+}
+
+// validateLogs 校验日志文件轮转与保留设置，0 为使用默认值
+func (v *configValidator) validateLogs(logs models.LogsConfig) {
+	const field = "manager.logs"
+	if logs.MaxSizeMB < 0 {
+		v.add(field+".max-size-mb", "文件大小不能为负数")
+	}
+	if logs.MaxAgeHours < 0 {
+		v.add(field+".max-age-hours", "轮转时长不能为负数")
+	}
+	if logs.RetentionDays < 0 {
+		v.add(field+".retention-days", "保留天数不能为负数")
+	}
+	if logs.MaxFiles < 0 {
+		v.add(field+".max-files", "文件数不能为负数")
+	}
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"go-wails/internal/models"
+	"strings"
+	"testing"
+)
+
+func TestValidateConfig(t *testing.T) {
+	priority := func(v int) *int { return &v }
+	tests := []struct {
+		name   string
+		modify func(cfg *models.XMRigConfig)
+		want   []string // 期望出错的字段，为空表示校验通过
+	}{
+		{
+			name:   "默认配置",
+			modify: func(cfg *models.XMRigConfig) {},
+		},
+		{
+			name: "没有矿池",
+			modify: func(cfg *models.XMRigConfig) {
+				cfg.Pools = nil
+			},
+			want: []string{"pools"},
+		},
+		{
+			name: "矿池全部停用",
+			modify: func(cfg *models.XMRigConfig) {
+				cfg.Pools[0].Enabled = false
+			},
+			want: []string{"pools"},
+		},
+		{
+			name: "矿池地址与钱包无效",
+			modify: func(cfg *models.XMRigConfig) {
+				cfg.Pools = append(cfg.Pools, models.PoolConfig{URL: "http://pool.example.com", User: "x", Enabled: true})
+			},
+			want: []string{"pools[1].url", "pools[1].user"},
+		},
+		{
+			name: "ssl 地址未开启 TLS",
+			modify: func(cfg *models.XMRigConfig) {
+				cfg.Pools[0].TLS = false
+			},
+			want: []string{"pools[0].tls"},
+		},
+		{
+			name: "钱包带矿机名",
+			modify: func(cfg *models.XMRigConfig) {
+				cfg.Pools[0].User = DefaultWalletAddress + ".rig1"
+			},
+		},
+		{
+			name: "HTTP 端口与地址无效",
+			modify: func(cfg *models.XMRigConfig) {
+				cfg.HTTP = models.HTTPConfig{Enabled: true, Host: "bad host", Port: 70000}
+			},
+			want: []string{"http.port", "http.host"},
+		},
+		{
+			name: "HTTP 未启用时不校验",
+			modify: func(cfg *models.XMRigConfig) {
+				cfg.HTTP = models.HTTPConfig{Host: "", Port: 0}
+			},
+		},
+		{
+			name: "线程比例为 0",
+			modify: func(cfg *models.XMRigConfig) {
+				cfg.CPU.MaxThreadsHint = 0
+			},
+			want: []string{"cpu.max-threads-hint"},
+		},
+		{
+			name: "线程比例与优先级越界",
+			modify: func(cfg *models.XMRigConfig) {
+				cfg.CPU.MaxThreadsHint = 101
+				cfg.CPU.Priority = priority(6)
+			},
+			want: []string{"cpu.max-threads-hint", "cpu.priority"},
+		},
+		{
+			name: "优先级边界",
+			modify: func(cfg *models.XMRigConfig) {
+				cfg.CPU.MaxThreadsHint = 1
+				cfg.CPU.Priority = priority(0)
+			},
+		},
+		{
+			name: "RandomX 模式无效",
+			modify: func(cfg *models.XMRigConfig) {
+				cfg.RandomX.Mode = "turbo"
+			},
+			want: []string{"randomx.mode"},
+		},
+		{
+			name: "负载调节使用默认阈值",
+			modify: func(cfg *models.XMRigConfig) {
+				cfg.HTTP = models.HTTPConfig{Enabled: true, Host: "127.0.0.1", Port: 18080}
+				cfg.Manager.Governor = models.GovernorConfig{Enabled: true, Mode: GovernorThreads}
+			},
+		},
+		{
+			name: "负载调节设置无效",
+			modify: func(cfg *models.XMRigConfig) {
+				cfg.HTTP = models.HTTPConfig{Enabled: true, Host: "127.0.0.1", Port: 18080, Restricted: true}
+				cfg.Manager.Governor = models.GovernorConfig{
+					Enabled:            true,
+					Mode:               "sleep",
+					HighLoad:           20,
+					LowLoad:            40,
+					ReducedThreadsHint: 101,
+				}
+			},
+			want: []string{
+				"manager.governor.mode",
+				"manager.governor.low-load",
+				"manager.governor.reduced-threads-hint",
+				"manager.governor.enabled",
+			},
+		},
+		{
+			name: "负载调节未开启时不校验",
+			modify: func(cfg *models.XMRigConfig) {
+				cfg.Manager.Governor = models.GovernorConfig{Mode: "sleep", ReducedThreadsHint: -1}
+			},
+		},
+		{
+			name: "日志设置为负数",
+			modify: func(cfg *models.XMRigConfig) {
+				cfg.Manager.Logs = models.LogsConfig{MaxSizeMB: -1, MaxAgeHours: -1, RetentionDays: -1, MaxFiles: -1}
+			},
+			want: []string{
+				"manager.logs.max-size-mb",
+				"manager.logs.max-age-hours",
+				"manager.logs.retention-days",
+				"manager.logs.max-files",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewConfigServiceAt(t.TempDir()).GetDefaultConfig()
+			tt.modify(cfg)
+			errs := ValidateConfig(cfg)
+			var got []string
+			for _, fe := range errs {
+				got = append(got, fe.Field)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Fatalf("出错字段得到 %v，期望 %v (%v)", got, tt.want, errs)
+			}
+		})
+	}
+
+	if errs := ValidateConfig(nil); len(errs) != 1 {
+		t.Errorf("空配置得到 %v，期望一个错误", errs)
+	}
+}
+
+func TestValidateConfigReducedThreadsHintMessage(t *testing.T) {
+	cfg := NewConfigServiceAt(t.TempDir()).GetDefaultConfig()
+	cfg.HTTP = models.HTTPConfig{Enabled: true, Host: "127.0.0.1", Port: 18080}
+	cfg.Manager.Governor = models.GovernorConfig{Enabled: true, ReducedThreadsHint: -1}
+	errs := ValidateConfig(cfg)
+	if len(errs) != 1 || !strings.Contains(errs[0].Message, "0-100") {
+		t.Fatalf("得到 %v，期望提示 0-100 之间", errs)
+	}
+
+	// 0 为使用默认值，可以通过校验
+	cfg.Manager.Governor.ReducedThreadsHint = 0
+	if errs := ValidateConfig(cfg); len(errs) != 0 {
+		t.Errorf("线程比例 0 得到 %v，期望通过", errs)
+	}
+}
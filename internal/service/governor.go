@@ -0,0 +1,367 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-wails/internal/events"
+	"go-wails/internal/models"
+	"go-wails/internal/sysload"
+	"sync"
+	"time"
+)
+
+// 负载调节方式
+const (
+	GovernorPause   = "pause"   // 通过 JSON-RPC 暂停与恢复
+	GovernorThreads = "threads" // 通过配置 API 临时降低线程比例
+)
+
+// 负载调节状态
+const (
+	GovernorInactive  = "inactive"
+	GovernorNormal    = "normal"
+	GovernorThrottled = "throttled"
+)
+
+// 负载调节默认策略
+const (
+	defaultGovernorInterval      = 5 * time.Second
+	defaultGovernorHighLoad      = 30.0
+	defaultGovernorLowLoad       = 15.0
+	defaultGovernorHighSamples   = 3
+	defaultGovernorLowSamples    = 12
+	defaultGovernorReducedThread = 25
+)
+
+// governorThresholds 返回应用默认值后的繁忙与空闲阈值
+func governorThresholds(g models.GovernorConfig) (high, low float64) {
+	high, low = g.HighLoad, g.LowLoad
+	if high <= 0 {
+		high = defaultGovernorHighLoad
+	}
+	if low <= 0 {
+		low = defaultGovernorLowLoad
+	}
+	return high, low
+}
+
+// Governor 周期采样其他程序的 CPU 占用，持续繁忙时暂停挖矿或降低线程，持续空闲后恢复。
+// 繁忙与空闲使用不同阈值并要求连续多次采样，避免在阈值附近反复切换
+type Governor struct {
+	xmrig     *XMRigService
+	configSvc *ConfigService
+	sampler   *sysload.Sampler
+	stopCh    chan struct{}
+	stopOnce  sync.Once
+	wg        sync.WaitGroup
+
+	mutex     sync.Mutex
+	status    models.GovernorStatus
+	pid       int
+	throttled bool
+	mode      string // 让出 CPU 时使用的方式，恢复时按同一方式撤销
+	savedHint int    // threads 方式下被替换的线程比例
+	highCount int
+	lowCount  int
+	lastError string
+}
+
+// governorAction 一次让出或归还 CPU，在锁内决定，在锁外通过 HTTP API 执行
+type governorAction struct {
+	throttle bool
+	mode     string // 让出 CPU 的方式，归还时为让出时使用的方式
+	hint     int    // threads 方式下要设置的线程比例
+	pid      int    // 决定时的矿工进程
+	load     float64
+	reason   string
+}
+
+// NewGovernor 创建负载调节
+func NewGovernor(xmrig *XMRigService, configSvc *ConfigService) *Governor {
+	return &Governor{
+		xmrig:     xmrig,
+		configSvc: configSvc,
+		sampler:   sysload.NewSampler(),
+		stopCh:    make(chan struct{}),
+		status:    models.GovernorStatus{Supported: true, State: GovernorInactive},
+	}
+}
+
+// Start 启动后台采样
+func (g *Governor) Start() {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		g.run()
+	}()
+}
+
+// Stop 停止采样，已让出的 CPU 会先归还
+func (g *Governor) Stop() {
+	g.stopOnce.Do(func() {
+		close(g.stopCh)
+		// 等待进行中的切换结束，之后不会再有其他地方修改状态
+		g.wg.Wait()
+
+		g.mutex.Lock()
+		var action *governorAction
+		if g.throttled {
+			action = &governorAction{mode: g.mode, hint: g.savedHint, pid: g.pid}
+		}
+		g.mutex.Unlock()
+		if action == nil {
+			return
+		}
+		cfg, err := g.configSvc.LoadConfig()
+		if err != nil {
+			return
+		}
+		if _, err := g.execute(cfg, action); err == nil {
+			g.mutex.Lock()
+			g.throttled = false
+			g.mutex.Unlock()
+		}
+	})
+}
+
+// Status 返回当前状态
+func (g *Governor) Status() models.GovernorStatus {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.status
+}
+
+func (g *Governor) run() {
+	for {
+		cfg, err := g.configSvc.LoadConfig()
+		interval := defaultGovernorInterval
+		if err == nil {
+			interval = secondsOr(cfg.Manager.Governor.SampleSeconds, defaultGovernorInterval)
+		}
+		select {
+		case <-g.stopCh:
+			return
+		case <-time.After(interval):
+		}
+		if err != nil {
+			continue
+		}
+
+		g.mutex.Lock()
+		action := g.check(cfg)
+		g.mutex.Unlock()
+		if action == nil {
+			continue
+		}
+		// 调用 XMRig 的 HTTP API 最长需要数秒，不持锁进行，期间状态查询不受影响
+		saved, err := g.execute(cfg, action)
+		g.mutex.Lock()
+		g.finish(action, saved, err)
+		g.mutex.Unlock()
+	}
+}
+
+// check 采样一次，需要切换时返回要执行的动作，调用方持有锁
+func (g *Governor) check(cfg *models.XMRigConfig) *governorAction {
+	policy := cfg.Manager.Governor
+	mode := policy.Mode
+	if mode == "" {
+		mode = GovernorPause
+	}
+	g.status.Enabled = policy.Enabled
+	g.status.Mode = mode
+
+	pid := g.xmrig.PID()
+	if pid != g.pid {
+		// 新进程使用渲染出的配置启动，之前的暂停或降线程已不再生效
+		g.reset(pid)
+	}
+	if !policy.Enabled || pid == 0 {
+		var action *governorAction
+		if g.throttled {
+			action = g.decide(cfg, false, g.status.Load, "负载调节已关闭")
+		}
+		g.status.State = GovernorInactive
+		g.highCount, g.lowCount = 0, 0
+		return action
+	}
+
+	load, ok, err := g.sampler.Sample(pid)
+	if errors.Is(err, sysload.ErrUnsupported) {
+		g.status.Supported = false
+		g.setError(err)
+		g.status.State = GovernorInactive
+		return nil
+	}
+	if err != nil {
+		g.setError(err)
+		return nil
+	}
+	if !ok {
+		return nil
+	}
+	g.status.Load = load
+
+	high, low := governorThresholds(policy)
+	switch {
+	case load >= high:
+		g.highCount++
+		g.lowCount = 0
+	case load <= low:
+		g.lowCount++
+		g.highCount = 0
+	default:
+		// 介于两个阈值之间保持当前状态
+		g.highCount, g.lowCount = 0, 0
+	}
+
+	var action *governorAction
+	if !g.throttled && g.highCount >= orInt(policy.HighSamples, defaultGovernorHighSamples) {
+		action = g.decide(cfg, true, load, fmt.Sprintf("其他程序 CPU 占用 %.0f%% 持续不低于 %.0f%%", load, high))
+	} else if g.throttled && g.lowCount >= orInt(policy.LowSamples, defaultGovernorLowSamples) {
+		action = g.decide(cfg, false, load, fmt.Sprintf("其他程序 CPU 占用 %.0f%% 持续不高于 %.0f%%", load, low))
+	}
+	if g.throttled {
+		g.status.State = GovernorThrottled
+	} else {
+		g.status.State = GovernorNormal
+	}
+	return action
+}
+
+// reset 切换到新的矿工进程
+func (g *Governor) reset(pid int) {
+	g.pid = pid
+	g.throttled = false
+	g.highCount, g.lowCount = 0, 0
+	g.sampler.Reset()
+}
+
+// decide 确定让出或归还 CPU 的方式，调用方持有锁
+func (g *Governor) decide(cfg *models.XMRigConfig, throttle bool, load float64, reason string) *governorAction {
+	g.highCount, g.lowCount = 0, 0
+	action := &governorAction{throttle: throttle, pid: g.pid, load: load, reason: reason}
+	if throttle {
+		action.mode = cfg.Manager.Governor.Mode
+		if action.mode == "" {
+			action.mode = GovernorPause
+		}
+		action.hint = orInt(cfg.Manager.Governor.ReducedThreadsHint, defaultGovernorReducedThread)
+	} else {
+		action.mode = g.mode
+		action.hint = g.savedHint
+	}
+	return action
+}
+
+// finish 记录切换结果并发布事件，调用方持有锁。执行期间矿工进程已更换时，新进程不受这次切换影响
+func (g *Governor) finish(action *governorAction, saved int, err error) {
+	if action.pid != g.pid {
+		return
+	}
+	if err != nil {
+		g.setError(err)
+		return
+	}
+	g.lastError = ""
+	g.status.Error = ""
+	g.throttled = action.throttle
+	if action.throttle {
+		g.mode = action.mode
+		g.savedHint = saved
+	}
+	if g.status.State != GovernorInactive {
+		g.status.State = GovernorNormal
+		if g.throttled {
+			g.status.State = GovernorThrottled
+		}
+	}
+
+	state, text := GovernorNormal, "恢复挖矿"
+	if action.throttle {
+		state, text = GovernorThrottled, "暂停挖矿"
+		if action.mode == GovernorThreads {
+			text = fmt.Sprintf("线程比例降至 %d%%", action.hint)
+		}
+	} else if action.mode == GovernorThreads {
+		text = "恢复线程比例"
+	}
+	g.xmrig.Events().Publish(events.GovernorChanged{State: state, Mode: action.mode, Load: action.load, Reason: action.reason})
+	g.xmrig.Notify(models.LogInfo, fmt.Sprintf("负载调节: %s，%s", text, action.reason))
+}
+
+// execute 通过 HTTP API 让出或归还 CPU，不需要持锁；降低线程时返回被替换的线程比例
+func (g *Governor) execute(cfg *models.XMRigConfig, action *governorAction) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client := g.xmrig.apiClient(cfg)
+	if action.throttle {
+		if !cfg.HTTP.Enabled || cfg.HTTP.Restricted {
+			return 0, fmt.Errorf("负载调节需要启用 HTTP API 并关闭 restricted")
+		}
+		if action.mode == GovernorPause {
+			if err := client.Pause(ctx); err != nil {
+				return 0, fmt.Errorf("暂停挖矿失败: %w", err)
+			}
+			return 0, nil
+		}
+		saved, err := g.setThreadsHint(ctx, cfg, action.hint)
+		if err != nil {
+			return 0, fmt.Errorf("降低线程比例失败: %w", err)
+		}
+		return saved, nil
+	}
+
+	if action.mode == GovernorThreads {
+		if _, err := g.setThreadsHint(ctx, cfg, action.hint); err != nil {
+			return 0, fmt.Errorf("恢复线程比例失败: %w", err)
+		}
+	} else if err := client.Resume(ctx); err != nil {
+		return 0, fmt.Errorf("恢复挖矿失败: %w", err)
+	}
+	return 0, nil
+}
+
+// setThreadsHint 通过 PUT /1/config 修改运行中的线程比例，返回修改前的值
+func (g *Governor) setThreadsHint(ctx context.Context, cfg *models.XMRigConfig, hint int) (int, error) {
+	client := g.xmrig.apiClient(cfg)
+	raw, err := client.Config(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var running map[string]interface{}
+	if err := json.Unmarshal(raw, &running); err != nil {
+		return 0, fmt.Errorf("解析运行中配置失败: %w", err)
+	}
+	cpu, _ := running["cpu"].(map[string]interface{})
+	if cpu == nil {
+		cpu = map[string]interface{}{}
+		running["cpu"] = cpu
+	}
+	previous := 100
+	if v, ok := cpu["max-threads-hint"].(float64); ok {
+		previous = int(v)
+	}
+	cpu["max-threads-hint"] = hint
+	return previous, client.UpdateConfig(ctx, running)
+}
+
+// setError 记录错误，同一错误只提示一次
+func (g *Governor) setError(err error) {
+	g.status.Error = err.Error()
+	if err.Error() == g.lastError {
+		return
+	}
+	g.lastError = err.Error()
+	g.xmrig.Notify(models.LogWarning, fmt.Sprintf("负载调节: %v", err))
+}
+
+func orInt(v, fallback int) int {
+	if v <= 0 {
+		return fallback
+	}
+	return v
+}
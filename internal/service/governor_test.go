@@ -0,0 +1,174 @@
+package service
+
+import (
+	"encoding/json"
+	"go-wails/internal/models"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeXMRigAPI 模拟 XMRig 的 HTTP API，记录收到的 JSON-RPC 方法与线程比例
+type fakeXMRigAPI struct {
+	mutex   sync.Mutex
+	methods []string
+	hint    float64
+	block   chan struct{} // 非空时请求在通道关闭前不返回
+	arrived chan struct{} // block 非空时每个请求到达后发送一次
+}
+
+func (f *fakeXMRigAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if f.block != nil {
+		f.arrived <- struct{}{}
+		<-f.block
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	switch {
+	case r.URL.Path == "/json_rpc":
+		var req struct {
+			Method string `json:"method"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		f.methods = append(f.methods, req.Method)
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": 1, "jsonrpc": "2.0", "result": map[string]string{"status": "OK"}})
+	case r.URL.Path == "/1/config" && r.Method == http.MethodGet:
+		json.NewEncoder(w).Encode(map[string]interface{}{"cpu": map[string]interface{}{"max-threads-hint": f.hint}})
+	case r.URL.Path == "/1/config" && r.Method == http.MethodPut:
+		var cfg struct {
+			CPU struct {
+				MaxThreadsHint float64 `json:"max-threads-hint"`
+			} `json:"cpu"`
+		}
+		json.NewDecoder(r.Body).Decode(&cfg)
+		f.hint = cfg.CPU.MaxThreadsHint
+		f.methods = append(f.methods, "config")
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// newTestGovernor 创建连接到假 HTTP API 的负载调节
+func newTestGovernor(t *testing.T, api *fakeXMRigAPI, mode string) (*Governor, *models.XMRigConfig) {
+	t.Helper()
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+	host, portText, _ := net.SplitHostPort(server.Listener.Addr().String())
+	port, _ := strconv.Atoi(portText)
+
+	xmrig, configSvc := newTestXMRig(t)
+	cfg, err := configSvc.LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.HTTP = models.HTTPConfig{Enabled: true, Host: host, Port: port}
+	cfg.Manager.Governor = models.GovernorConfig{Enabled: true, Mode: mode, ReducedThreadsHint: 30}
+	if err := configSvc.SaveConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+	return NewGovernor(xmrig, configSvc), cfg
+}
+
+func TestGovernorThreadsTransition(t *testing.T) {
+	api := &fakeXMRigAPI{hint: 80}
+	g, cfg := newTestGovernor(t, api, GovernorThreads)
+
+	g.mutex.Lock()
+	action := g.decide(cfg, true, 90, "繁忙")
+	g.mutex.Unlock()
+	saved, err := g.execute(cfg, action)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.mutex.Lock()
+	g.finish(action, saved, err)
+	throttled, savedHint := g.throttled, g.savedHint
+	g.mutex.Unlock()
+	if !throttled || savedHint != 80 || api.hint != 30 {
+		t.Fatalf("降低线程后 throttled=%v 保存=%d 运行中=%v，期望 true/80/30", throttled, savedHint, api.hint)
+	}
+
+	// 归还时恢复被替换的线程比例
+	g.mutex.Lock()
+	action = g.decide(cfg, false, 5, "空闲")
+	g.mutex.Unlock()
+	saved, err = g.execute(cfg, action)
+	g.mutex.Lock()
+	g.finish(action, saved, err)
+	throttled = g.throttled
+	g.mutex.Unlock()
+	if err != nil || throttled || api.hint != 80 {
+		t.Fatalf("恢复后 err=%v throttled=%v 运行中=%v，期望恢复为 80", err, throttled, api.hint)
+	}
+}
+
+func TestGovernorIgnoresResultForReplacedProcess(t *testing.T) {
+	api := &fakeXMRigAPI{}
+	g, cfg := newTestGovernor(t, api, GovernorPause)
+
+	g.mutex.Lock()
+	action := g.decide(cfg, true, 90, "繁忙")
+	g.mutex.Unlock()
+	saved, err := g.execute(cfg, action)
+
+	// 执行期间矿工进程已更换
+	g.mutex.Lock()
+	g.reset(action.pid + 1)
+	g.finish(action, saved, err)
+	throttled := g.throttled
+	g.mutex.Unlock()
+	if throttled {
+		t.Error("旧进程的暂停结果被记到了新进程上")
+	}
+}
+
+func TestGovernorStopRestoresWithoutLock(t *testing.T) {
+	api := &fakeXMRigAPI{block: make(chan struct{}), arrived: make(chan struct{}, 16)}
+	g, _ := newTestGovernor(t, api, GovernorPause)
+	g.throttled = true
+	g.mode = GovernorPause
+
+	stopped := make(chan struct{})
+	go func() {
+		g.Stop()
+		close(stopped)
+	}()
+
+	select {
+	case <-api.arrived:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Stop 没有调用 HTTP API 恢复挖矿")
+	}
+	// 等待 HTTP API 响应期间仍可查询状态
+	queried := make(chan struct{})
+	go func() {
+		g.Status()
+		close(queried)
+	}()
+	select {
+	case <-queried:
+	case <-time.After(5 * time.Second):
+		close(api.block)
+		t.Fatal("调用 HTTP API 时持有锁，状态查询被阻塞")
+	}
+
+	close(api.block)
+	select {
+	case <-stopped:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Stop 没有结束")
+	}
+	g.mutex.Lock()
+	throttled := g.throttled
+	g.mutex.Unlock()
+	api.mutex.Lock()
+	defer api.mutex.Unlock()
+	if throttled || len(api.methods) != 1 || api.methods[0] != "resume" {
+		t.Fatalf("停止后 throttled=%v 调用=%v，期望恢复挖矿", throttled, api.methods)
+	}
+}
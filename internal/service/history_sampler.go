@@ -0,0 +1,125 @@
+package service
+
+import (
+	"fmt"
+	"go-wails/internal/history"
+	"go-wails/internal/models"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// defaultSampleInterval 默认采样间隔
+const defaultSampleInterval = time.Minute
+
+// HistorySampler 后台定期采样挖矿状态并写入历史存储
+type HistorySampler struct {
+	xmrig      *XMRigService
+	configSvc  *ConfigService
+	store      *history.Store
+	stopCh     chan struct{}
+	stopOnce   sync.Once
+	wg         sync.WaitGroup
+	wasRunning bool
+	lastError  string
+}
+
+// NewHistorySampler 创建采样器，数据保存在数据目录的 history 子目录
+func NewHistorySampler(xmrig *XMRigService, configSvc *ConfigService) *HistorySampler {
+	return &HistorySampler{
+		xmrig:     xmrig,
+		configSvc: configSvc,
+		store:     history.NewStore(filepath.Join(configSvc.GetDataDir(), "history"), 0),
+		stopCh:    make(chan struct{}),
+	}
+}
+
+// Start 启动后台采样
+func (h *HistorySampler) Start() {
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		h.run()
+	}()
+}
+
+// Stop 停止采样，等待正在进行的写入结束后关闭存储
+func (h *HistorySampler) Stop() {
+	h.stopOnce.Do(func() {
+		close(h.stopCh)
+		h.wg.Wait()
+		_ = h.store.Close()
+	})
+}
+
+// Query 查询最近 rangeDuration 内的历史，resolution 为 0 时自动选择
+func (h *HistorySampler) Query(rangeDuration, resolution time.Duration) ([]models.HistorySample, error) {
+	if rangeDuration <= 0 {
+		return nil, fmt.Errorf("查询范围必须大于0")
+	}
+	to := time.Now()
+	return h.store.Query(to.Add(-rangeDuration), to, resolution)
+}
+
+func (h *HistorySampler) run() {
+	for {
+		cfg := h.loadConfig()
+		interval := secondsOr(cfg.IntervalSeconds, defaultSampleInterval)
+		h.store.SetRetention(cfg.RetentionDays)
+
+		select {
+		case <-h.stopCh:
+			return
+		case <-time.After(interval):
+		}
+
+		if !cfg.Disabled {
+			h.sample()
+		}
+	}
+}
+
+// sample 记录一次采样；停止状态只在刚停止时记录一次，避免空闲时写入大量数据
+func (h *HistorySampler) sample() {
+	running := h.xmrig.IsRunning()
+	if !running && !h.wasRunning {
+		return
+	}
+	h.wasRunning = running
+
+	sample := models.HistorySample{
+		Time:    time.Now().Unix(),
+		Running: running,
+	}
+	if running {
+		status, err := h.xmrig.GetStatus()
+		if err == nil {
+			sample.Hashrate = status.Hashrate
+			sample.Hashrate60s = status.Hashrate60s
+			sample.SharesAccepted = status.SharesAccepted
+			sample.SharesRejected = status.SharesRejected
+			sample.Threads = status.Threads
+			sample.Pool = status.Pool
+			sample.Connected = status.Connected
+		}
+	}
+
+	if err := h.store.Append(sample); err != nil {
+		// 同一错误只提示一次，避免每次采样都写一条日志
+		if err.Error() != h.lastError {
+			h.lastError = err.Error()
+			h.xmrig.Notify(models.LogWarning, fmt.Sprintf("写入算力历史失败: %v", err))
+		}
+		return
+	}
+	h.lastError = ""
+}
+
+// loadConfig 读取采样配置
+func (h *HistorySampler) loadConfig() models.HistoryConfig {
+	cfg, err := h.configSvc.LoadConfig()
+	if err != nil {
+		return models.HistoryConfig{}
+	}
+	return cfg.Manager.History
+}
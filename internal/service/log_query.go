@@ -0,0 +1,143 @@
+package service
+
+import (
+	"fmt"
+	"go-wails/internal/models"
+	"regexp"
+	"strings"
+)
+
+// 日志查询每页条数
+const (
+	defaultLogPageSize = 200
+	maxLogPageSize     = 1000
+)
+
+// logMatcher 编译后的日志查询条件
+type logMatcher struct {
+	query   models.LogQuery
+	levels  map[string]bool
+	tags    map[string]bool
+	sources map[string]bool
+	text    string
+	pattern *regexp.Regexp
+}
+
+func newLogMatcher(q models.LogQuery) (*logMatcher, error) {
+	m := &logMatcher{
+		query:   q,
+		levels:  stringSet(q.Levels),
+		tags:    stringSet(q.Tags),
+		sources: stringSet(q.Sources),
+	}
+	if q.Regex && q.Text != "" {
+		pattern, err := regexp.Compile(q.Text)
+		if err != nil {
+			return nil, fmt.Errorf("无效的正则表达式: %w", err)
+		}
+		m.pattern = pattern
+	} else {
+		m.text = strings.ToLower(q.Text)
+	}
+	return m, nil
+}
+
+func (m *logMatcher) match(r models.LogRecord) bool {
+	if m.levels != nil && !m.levels[r.Level] {
+		return false
+	}
+	if m.tags != nil && !m.tags[r.Tag] {
+		return false
+	}
+	if m.sources != nil && !m.sources[r.Source] {
+		return false
+	}
+	if m.query.From > 0 && r.Time < m.query.From {
+		return false
+	}
+	if m.query.To > 0 && r.Time >= m.query.To {
+		return false
+	}
+	if m.pattern != nil {
+		return m.pattern.MatchString(r.Line)
+	}
+	return m.text == "" || strings.Contains(strings.ToLower(r.Line), m.text)
+}
+
+// stringSet 列表转换为集合，空列表返回 nil 表示不过滤
+func stringSet(values []string) map[string]bool {
+	if len(values) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[strings.ToLower(strings.TrimSpace(v))] = true
+	}
+	return set
+}
+
+// QueryLogs 按条件查询日志缓冲区。
+// 默认返回最新的一页，Before 向更早的日志翻页；After 大于 0 时为追踪模式，
+// 从该序号之后最早的日志开始返回，界面与命令行只需获取新增的日志。
+// 只查询内存中最近 maxLogLines 条日志，更早的日志与历次运行的日志需通过日志文件查看
+func (s *XMRigService) QueryLogs(q models.LogQuery) (models.LogPage, error) {
+	m, err := newLogMatcher(q)
+	if err != nil {
+		return models.LogPage{}, err
+	}
+	limit := q.Limit
+	if limit <= 0 {
+		limit = defaultLogPageSize
+	}
+	if limit > maxLogPageSize {
+		limit = maxLogPageSize
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	page := models.LogPage{Records: []models.LogRecord{}, Latest: s.logSeq}
+	if q.After > 0 {
+		// 缓冲区中最早的序号与 After 不相接，说明中间的日志已被移出
+		oldest := s.logSeq + 1
+		if len(s.logBuffer) > 0 {
+			oldest = s.logBuffer[0].Seq
+		}
+		page.Truncated = oldest > q.After+1
+		for _, r := range s.logBuffer {
+			if r.Seq <= q.After || !m.match(r) {
+				continue
+			}
+			if len(page.Records) == limit {
+				page.More = true
+				break
+			}
+			page.Records = append(page.Records, r)
+		}
+		if page.More {
+			page.Latest = page.Records[len(page.Records)-1].Seq
+		}
+		return page, nil
+	}
+
+	// 从最新的日志向前收集，多找一条判断是否还有更早的匹配
+	var found []models.LogRecord
+	for i := len(s.logBuffer) - 1; i >= 0; i-- {
+		r := s.logBuffer[i]
+		if q.Before > 0 && r.Seq >= q.Before {
+			continue
+		}
+		if !m.match(r) {
+			continue
+		}
+		if len(found) == limit {
+			page.Cursor = found[len(found)-1].Seq
+			break
+		}
+		found = append(found, r)
+	}
+	for i := len(found) - 1; i >= 0; i-- {
+		page.Records = append(page.Records, found[i])
+	}
+	return page, nil
+}
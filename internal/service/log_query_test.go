@@ -0,0 +1,156 @@
+package service
+
+import (
+	"go-wails/internal/models"
+	"testing"
+)
+
+// newLogBuffer 返回只保留序号 first 到 last 日志的服务，偶数序号为错误日志
+func newLogBuffer(first, last uint64) *XMRigService {
+	s := &XMRigService{logSeq: last, maxLogLines: 2000}
+	for seq := first; seq <= last; seq++ {
+		level := models.LogInfo
+		if seq%2 == 0 {
+			level = models.LogError
+		}
+		s.logBuffer = append(s.logBuffer, models.LogRecord{Seq: seq, Level: level, Source: "stdout", Line: "line"})
+	}
+	return s
+}
+
+func TestQueryLogs(t *testing.T) {
+	full := newLogBuffer(1, 10)
+	// 缓冲区已满，序号 1 到 3 被移出
+	trimmed := newLogBuffer(4, 10)
+	// 清空日志后缓冲区为空，序号保持递增
+	cleared := newLogBuffer(1, 0)
+	cleared.logSeq = 10
+
+	tests := []struct {
+		name          string
+		svc           *XMRigService
+		query         models.LogQuery
+		want          []uint64
+		wantCursor    uint64
+		wantLatest    uint64
+		wantMore      bool
+		wantTruncated bool
+	}{
+		{
+			name:       "最新一页",
+			svc:        full,
+			query:      models.LogQuery{Limit: 3},
+			want:       []uint64{8, 9, 10},
+			wantCursor: 8,
+			wantLatest: 10,
+		},
+		{
+			name:       "按游标向前翻页",
+			svc:        full,
+			query:      models.LogQuery{Before: 8, Limit: 3},
+			want:       []uint64{5, 6, 7},
+			wantCursor: 5,
+			wantLatest: 10,
+		},
+		{
+			name:       "翻到最早一页时游标为零",
+			svc:        full,
+			query:      models.LogQuery{Before: 3, Limit: 3},
+			want:       []uint64{1, 2},
+			wantLatest: 10,
+		},
+		{
+			name:       "按级别过滤后翻页",
+			svc:        full,
+			query:      models.LogQuery{Levels: []string{"error"}, Before: 9, Limit: 2},
+			want:       []uint64{6, 8},
+			wantCursor: 6,
+			wantLatest: 10,
+		},
+		{
+			name:       "追踪模式分批返回",
+			svc:        full,
+			query:      models.LogQuery{After: 5, Limit: 3},
+			want:       []uint64{6, 7, 8},
+			wantLatest: 8,
+			wantMore:   true,
+		},
+		{
+			name:       "追踪模式取完剩余日志",
+			svc:        full,
+			query:      models.LogQuery{After: 8, Limit: 3},
+			want:       []uint64{9, 10},
+			wantLatest: 10,
+		},
+		{
+			name:       "追踪模式没有新日志",
+			svc:        full,
+			query:      models.LogQuery{After: 10},
+			want:       []uint64{},
+			wantLatest: 10,
+		},
+		{
+			name:       "追踪模式过滤后仍推进到最新序号",
+			svc:        full,
+			query:      models.LogQuery{After: 6, Levels: []string{"info"}},
+			want:       []uint64{7, 9},
+			wantLatest: 10,
+		},
+		{
+			name:          "追踪模式中间日志已移出缓冲区",
+			svc:           trimmed,
+			query:         models.LogQuery{After: 1, Limit: 3},
+			want:          []uint64{4, 5, 6},
+			wantLatest:    6,
+			wantMore:      true,
+			wantTruncated: true,
+		},
+		{
+			name:       "追踪模式与缓冲区相接",
+			svc:        trimmed,
+			query:      models.LogQuery{After: 3, Limit: 3},
+			want:       []uint64{4, 5, 6},
+			wantLatest: 6,
+			wantMore:   true,
+		},
+		{
+			name:          "清空日志后追踪",
+			svc:           cleared,
+			query:         models.LogQuery{After: 5},
+			want:          []uint64{},
+			wantLatest:    10,
+			wantTruncated: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := tt.svc.QueryLogs(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := []uint64{}
+			for _, r := range page.Records {
+				got = append(got, r.Seq)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("得到 %v，期望 %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("得到 %v，期望 %v", got, tt.want)
+				}
+			}
+			if page.Cursor != tt.wantCursor || page.Latest != tt.wantLatest || page.More != tt.wantMore || page.Truncated != tt.wantTruncated {
+				t.Errorf("得到 Cursor=%d Latest=%d More=%v Truncated=%v，期望 Cursor=%d Latest=%d More=%v Truncated=%v",
+					page.Cursor, page.Latest, page.More, page.Truncated,
+					tt.wantCursor, tt.wantLatest, tt.wantMore, tt.wantTruncated)
+			}
+		})
+	}
+}
+
+func TestQueryLogsInvalidRegex(t *testing.T) {
+	if _, err := newLogBuffer(1, 1).QueryLogs(models.LogQuery{Text: "(", Regex: true}); err == nil {
+		t.Error("无效的正则表达式应当返回错误")
+	}
+}
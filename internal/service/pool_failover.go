@@ -0,0 +1,264 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-wails/internal/events"
+	"go-wails/internal/models"
+	"sync"
+	"time"
+)
+
+// 矿池切换方式
+const (
+	FailoverAuto    = "auto"
+	FailoverAPI     = "api"
+	FailoverRestart = "restart"
+)
+
+// 故障切换默认策略
+const (
+	defaultFailoverInterval     = 30 * time.Second
+	defaultDisconnectedDuration = 2 * time.Minute
+	defaultMaxRejectRatio       = 0.5
+	defaultMinShares            = 10
+)
+
+// SwitchPool 将 target 指定的矿池调整为当前运行的首选矿池并立即生效，
+// 优先通过XMRig配置API热切换，不可用时经监督器受控重启；用户设置中的矿池顺序保持不变
+func (s *Supervisor) SwitchPool(target, reason, method string) error {
+	xmrig := s.xmrig
+	cfg, err := s.configSvc.LoadConfig()
+	if err != nil {
+		return err
+	}
+	index := poolIndex(cfg.Pools, target)
+	if index == -1 {
+		return fmt.Errorf("矿池不存在或未启用: %s", target)
+	}
+	xmrig.mutex.RLock()
+	from := xmrig.currentPool
+	xmrig.mutex.RUnlock()
+
+	if method == "" {
+		method = FailoverAuto
+	}
+	applied := false
+	if method != FailoverRestart && cfg.HTTP.Enabled && !cfg.HTTP.Restricted {
+		if err := xmrig.applyPoolsViaAPI(cfg, index); err == nil {
+			applied = true
+			xmrig.mutex.Lock()
+			xmrig.currentPool = target
+			xmrig.mutex.Unlock()
+		} else if method == FailoverAPI {
+			return fmt.Errorf("通过XMRig API切换矿池失败: %w", err)
+		}
+	} else if method == FailoverAPI {
+		return fmt.Errorf("通过XMRig API切换矿池需要启用HTTP API并关闭restricted")
+	}
+
+	if !applied && xmrig.IsRunning() {
+		if err := s.Restart(target); err != nil {
+			return fmt.Errorf("切换矿池后重启失败: %w", err)
+		}
+	}
+
+	xmrig.bus.Publish(events.PoolSwitched{From: from, To: target, Reason: reason})
+	xmrig.Notify(models.LogWarning, fmt.Sprintf("矿池已切换 %s -> %s: %s", from, target, reason))
+	return nil
+}
+
+// applyPoolsViaAPI 通过 PUT /1/config 将运行中XMRig的首选矿池调整为 index 指定的矿池
+func (s *XMRigService) applyPoolsViaAPI(cfg *models.XMRigConfig, index int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client := s.apiClient(cfg)
+	raw, err := client.Config(ctx)
+	if err != nil {
+		return err
+	}
+	var running map[string]interface{}
+	if err := json.Unmarshal(raw, &running); err != nil {
+		return fmt.Errorf("解析运行中配置失败: %w", err)
+	}
+
+	poolsData, err := json.Marshal(renderPools(cfg.Pools, index))
+	if err != nil {
+		return err
+	}
+	var pools []interface{}
+	if err := json.Unmarshal(poolsData, &pools); err != nil {
+		return err
+	}
+	running["pools"] = pools
+	return client.UpdateConfig(ctx, running)
+}
+
+// PoolMonitor 挖矿期间监控矿池连接与份额拒绝率，按策略切换到下一个健康矿池
+type PoolMonitor struct {
+	supervisor *Supervisor
+	xmrig      *XMRigService
+	configSvc  *ConfigService
+	stopCh     chan struct{}
+	stopOnce   sync.Once
+
+	pool              string
+	disconnectedSince time.Time
+	shares            shareWindow
+	lastSwitch        time.Time
+}
+
+// shareWindow 以某一时刻的累计份额为基准，统计当前矿池之后的接受与拒绝数。
+// 热切换矿池时 XMRig 的计数不会归零，必须在切换后重新取基准，
+// 否则旧矿池的拒绝会算到新矿池上
+type shareWindow struct {
+	pool     string
+	accepted uint64
+	rejected uint64
+}
+
+// observe 记录一次累计计数，返回相对基准的接受与拒绝数。
+// 矿池变化或 reset 后以本次计数为基准；计数变小说明进程已重启，基准归零
+func (w *shareWindow) observe(pool string, accepted, rejected uint64) (uint64, uint64) {
+	if pool != w.pool {
+		w.pool, w.accepted, w.rejected = pool, accepted, rejected
+	} else if accepted < w.accepted || rejected < w.rejected {
+		w.accepted, w.rejected = 0, 0
+	}
+	return accepted - w.accepted, rejected - w.rejected
+}
+
+// reset 丢弃基准，下次 observe 时重新取
+func (w *shareWindow) reset() {
+	*w = shareWindow{}
+}
+
+// NewPoolMonitor 创建矿池监控，需要重启时经监督器进行
+func NewPoolMonitor(supervisor *Supervisor, configSvc *ConfigService) *PoolMonitor {
+	return &PoolMonitor{
+		supervisor: supervisor,
+		xmrig:      supervisor.xmrig,
+		configSvc:  configSvc,
+		stopCh:     make(chan struct{}),
+	}
+}
+
+// Start 启动后台监控
+func (m *PoolMonitor) Start() {
+	go m.run()
+}
+
+// Stop 停止监控
+func (m *PoolMonitor) Stop() {
+	m.stopOnce.Do(func() { close(m.stopCh) })
+}
+
+func (m *PoolMonitor) run() {
+	for {
+		policy := m.loadPolicy()
+		select {
+		case <-m.stopCh:
+			return
+		case <-time.After(secondsOr(policy.CheckIntervalSeconds, defaultFailoverInterval)):
+		}
+		if policy.Disabled || !m.xmrig.IsRunning() {
+			m.reset("")
+			continue
+		}
+		m.check(policy)
+	}
+}
+
+// reset 重置针对当前矿池的统计
+func (m *PoolMonitor) reset(pool string) {
+	m.pool = pool
+	m.disconnectedSince = time.Time{}
+	m.shares.reset()
+}
+
+// check 检查当前矿池，必要时切换
+func (m *PoolMonitor) check(policy models.FailoverConfig) {
+	status, err := m.xmrig.GetStatus()
+	if err != nil || !status.Running {
+		return
+	}
+	if status.Pool != m.pool {
+		m.reset(status.Pool)
+	}
+	accepted, rejected := m.shares.observe(status.Pool, status.SharesAccepted, status.SharesRejected)
+
+	grace := secondsOr(policy.DisconnectedSeconds, defaultDisconnectedDuration)
+	now := time.Now()
+	if !m.lastSwitch.IsZero() && now.Sub(m.lastSwitch) < grace {
+		// 刚切换过，给新矿池留出连接时间
+		return
+	}
+
+	reason := ""
+	if m.xmrig.PoolConnected() {
+		m.disconnectedSince = time.Time{}
+	} else if m.disconnectedSince.IsZero() {
+		m.disconnectedSince = now
+	} else if now.Sub(m.disconnectedSince) >= grace {
+		reason = fmt.Sprintf("与矿池断开超过 %s", grace)
+	}
+
+	if reason == "" {
+		minShares := policy.MinShares
+		if minShares <= 0 {
+			minShares = defaultMinShares
+		}
+		maxRatio := policy.MaxRejectRatio
+		if maxRatio <= 0 {
+			maxRatio = defaultMaxRejectRatio
+		}
+		if total := accepted + rejected; total >= uint64(minShares) {
+			ratio := float64(rejected) / float64(total)
+			if ratio > maxRatio {
+				reason = fmt.Sprintf("份额拒绝率 %.0f%% 超过 %.0f%%", ratio*100, maxRatio*100)
+			}
+		}
+	}
+	if reason == "" {
+		return
+	}
+
+	next := m.nextHealthyPool(status.Pool)
+	if next == "" {
+		m.xmrig.Notify(models.LogError, fmt.Sprintf("%s，但没有其他可用矿池", reason))
+		m.lastSwitch = now
+		return
+	}
+
+	m.lastSwitch = now
+	if err := m.supervisor.SwitchPool(next, reason, policy.Method); err != nil {
+		m.xmrig.Notify(models.LogError, fmt.Sprintf("切换矿池失败: %v", err))
+		return
+	}
+	// 清空当前矿池，下次检查时以切换后的累计计数为基准
+	m.reset("")
+}
+
+// nextHealthyPool 按配置的选择策略，在 current 以外能登录并下发任务的已启用矿池中选择
+func (m *PoolMonitor) nextHealthyPool(current string) string {
+	cfg, err := m.configSvc.LoadConfig()
+	if err != nil {
+		return ""
+	}
+	index, _, err := m.xmrig.selector.Select(cfg.Pools, cfg.Manager.PoolStrategy, current)
+	if err != nil {
+		return ""
+	}
+	return cfg.Pools[index].URL
+}
+
+// loadPolicy 读取故障切换策略
+func (m *PoolMonitor) loadPolicy() models.FailoverConfig {
+	cfg, err := m.configSvc.LoadConfig()
+	if err != nil {
+		return models.FailoverConfig{}
+	}
+	return cfg.Manager.Failover
+}
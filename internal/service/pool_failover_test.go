@@ -0,0 +1,66 @@
+package service
+
+import "testing"
+
+func TestShareWindowObserve(t *testing.T) {
+	type step struct {
+		pool               string
+		accepted, rejected uint64
+		reset              bool // 在本次 observe 之前调用 reset
+		wantAcc, wantRej   uint64
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "首次观察取基准",
+			steps: []step{
+				{pool: "a", accepted: 100, rejected: 40},
+				{pool: "a", accepted: 110, rejected: 45, wantAcc: 10, wantRej: 5},
+			},
+		},
+		{
+			// 热切换后 XMRig 继续累计，旧矿池的拒绝不能算到新矿池上
+			name: "热切换后重新取基准",
+			steps: []step{
+				{pool: "a", accepted: 10, rejected: 0},
+				{pool: "a", accepted: 12, rejected: 30, wantAcc: 2, wantRej: 30},
+				{pool: "b", accepted: 12, rejected: 30, reset: true},
+				{pool: "b", accepted: 20, rejected: 30, wantAcc: 8, wantRej: 0},
+			},
+		},
+		{
+			// 状态中的矿池尚未更新时，reset 也要让下一次观察重新取基准
+			name: "切换后矿池地址相同",
+			steps: []step{
+				{pool: "b", accepted: 5, rejected: 0},
+				{pool: "b", accepted: 5, rejected: 50, wantAcc: 0, wantRej: 50},
+				{pool: "b", accepted: 5, rejected: 50, reset: true},
+				{pool: "b", accepted: 9, rejected: 50, wantAcc: 4, wantRej: 0},
+			},
+		},
+		{
+			name: "进程重启计数归零",
+			steps: []step{
+				{pool: "a", accepted: 100, rejected: 10},
+				{pool: "a", accepted: 3, rejected: 1, wantAcc: 3, wantRej: 1},
+				{pool: "a", accepted: 8, rejected: 1, wantAcc: 8, wantRej: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var w shareWindow
+			for i, s := range tt.steps {
+				if s.reset {
+					w.reset()
+				}
+				acc, rej := w.observe(s.pool, s.accepted, s.rejected)
+				if acc != s.wantAcc || rej != s.wantRej {
+					t.Fatalf("第 %d 步: 得到 (%d, %d)，期望 (%d, %d)", i, acc, rej, s.wantAcc, s.wantRej)
+				}
+			}
+		})
+	}
+}
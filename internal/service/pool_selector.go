@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-wails/internal/models"
+	"go-wails/internal/stratum"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 矿池选择策略
+const (
+	PoolStrategyOrdered        = "ordered"
+	PoolStrategyLowestLatency  = "lowest-latency"
+	PoolStrategyWeightedRandom = "weighted-random"
+	PoolStrategySticky         = "sticky"
+)
+
+// probeFunc 探测单个矿池
+type probeFunc func(ctx context.Context, pool models.PoolConfig) stratum.ProbeResult
+
+// PoolSelector 并行探测已启用的矿池，按策略选出首选矿池
+type PoolSelector struct {
+	probe     probeFunc
+	statePath string
+	mutex     sync.Mutex
+	lastGood  string
+	last      *models.PoolSelection
+	rand      *rand.Rand
+}
+
+// poolState 持久化的选择状态
+type poolState struct {
+	LastGood string `json:"last-good"`
+}
+
+// NewPoolSelector 创建矿池选择器，状态保存在 stateDir 下
+func NewPoolSelector(probe probeFunc, stateDir string) *PoolSelector {
+	ps := &PoolSelector{
+		probe:     probe,
+		statePath: filepath.Join(stateDir, "pool-state.json"),
+		rand:      rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	if data, err := os.ReadFile(ps.statePath); err == nil {
+		var state poolState
+		if json.Unmarshal(data, &state) == nil {
+			ps.lastGood = state.LastGood
+		}
+	}
+	return ps
+}
+
+// Select 在共享的截止时间内并行探测所有已启用矿池（跳过 exclude），
+// 按策略返回选中矿池的下标；没有可用矿池时返回错误，选择结果仍会记录
+func (ps *PoolSelector) Select(pools []models.PoolConfig, strategy, exclude string) (int, *models.PoolSelection, error) {
+	strategy = normalizeStrategy(strategy)
+	selection := &models.PoolSelection{
+		Strategy: strategy,
+		Time:     time.Now().Unix(),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), stratum.DefaultProbeTimeout)
+	defer cancel()
+
+	var indexes []int
+	for i, p := range pools {
+		if p.Enabled && p.URL != exclude {
+			indexes = append(indexes, i)
+		}
+	}
+	results := make([]stratum.ProbeResult, len(indexes))
+	var wg sync.WaitGroup
+	for n, i := range indexes {
+		wg.Add(1)
+		go func(n int, pool models.PoolConfig) {
+			defer wg.Done()
+			results[n] = ps.probe(ctx, pool)
+		}(n, pools[i])
+	}
+	wg.Wait()
+
+	var healthy []int
+	var failures []string
+	for n, i := range indexes {
+		r := results[n]
+		selection.Probes = append(selection.Probes, models.PoolProbe{
+			URL:       pools[i].URL,
+			OK:        r.OK,
+			Class:     r.Class,
+			Error:     r.Error,
+			ConnectMs: durationMs(r.ConnectTime),
+			LatencyMs: durationMs(r.Latency),
+		})
+		if r.OK {
+			healthy = append(healthy, n)
+		} else {
+			failures = append(failures, fmt.Sprintf("%s: %v", pools[i].URL, r.Err()))
+		}
+	}
+
+	chosen := -1
+	if len(healthy) > 0 {
+		n := ps.choose(strategy, pools, indexes, healthy, results)
+		chosen = indexes[n]
+		selection.Chosen = pools[chosen].URL
+	}
+
+	ps.mutex.Lock()
+	ps.last = selection
+	ps.mutex.Unlock()
+
+	if chosen == -1 {
+		if len(failures) == 0 {
+			return -1, selection, fmt.Errorf("没有可用的矿池，请检查配置是否正确")
+		}
+		return -1, selection, fmt.Errorf("没有可用的矿池，请检查配置是否正确（%s）", strings.Join(failures, "；"))
+	}
+	return chosen, selection, nil
+}
+
+// choose 在健康矿池中按策略选择，返回 indexes/results 中的位置
+func (ps *PoolSelector) choose(strategy string, pools []models.PoolConfig, indexes, healthy []int, results []stratum.ProbeResult) int {
+	switch strategy {
+	case PoolStrategyLowestLatency:
+		best := healthy[0]
+		for _, n := range healthy[1:] {
+			if results[n].Latency < results[best].Latency {
+				best = n
+			}
+		}
+		return best
+	case PoolStrategyWeightedRandom:
+		total := 0
+		for _, n := range healthy {
+			total += poolWeight(pools[indexes[n]])
+		}
+		ps.mutex.Lock()
+		pick := ps.rand.Intn(total)
+		ps.mutex.Unlock()
+		for _, n := range healthy {
+			pick -= poolWeight(pools[indexes[n]])
+			if pick < 0 {
+				return n
+			}
+		}
+	case PoolStrategySticky:
+		ps.mutex.Lock()
+		lastGood := ps.lastGood
+		ps.mutex.Unlock()
+		for _, n := range healthy {
+			if pools[indexes[n]].URL == lastGood {
+				return n
+			}
+		}
+	}
+	// ordered，以及 sticky 上次的矿池不可用时
+	return healthy[0]
+}
+
+// MarkGood 记录最近一次成功工作的矿池，供 sticky 策略使用
+func (ps *PoolSelector) MarkGood(url string) {
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+	if url == "" || url == ps.lastGood {
+		return
+	}
+	ps.lastGood = url
+	data, err := json.MarshalIndent(poolState{LastGood: url}, "", "    ")
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(ps.statePath), 0755); err == nil {
+		_ = os.WriteFile(ps.statePath, data, 0644)
+	}
+}
+
+// Last 返回最近一次选择结果
+func (ps *PoolSelector) Last() *models.PoolSelection {
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+	return ps.last
+}
+
+// normalizeStrategy 未设置或未知的策略按 ordered 处理
+func normalizeStrategy(strategy string) string {
+	switch strategy {
+	case PoolStrategyLowestLatency, PoolStrategyWeightedRandom, PoolStrategySticky:
+		return strategy
+	default:
+		return PoolStrategyOrdered
+	}
+}
+
+func poolWeight(p models.PoolConfig) int {
+	if p.Weight <= 0 {
+		return 1
+	}
+	return p.Weight
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
@@ -0,0 +1,14 @@
+//go:build !windows
+
+package service
+
+import (
+	"os"
+	"syscall"
+)
+
+// ownedByCurrentUser 判断文件是否属于当前用户
+func ownedByCurrentUser(info os.FileInfo) bool {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	return ok && int(st.Uid) == os.Getuid()
+}
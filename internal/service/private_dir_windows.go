@@ -0,0 +1,10 @@
+//go:build windows
+
+package service
+
+import "os"
+
+// ownedByCurrentUser 数据目录位于用户的 AppData 中，由 ACL 限制其他用户访问，不再检查所有者
+func ownedByCurrentUser(info os.FileInfo) bool {
+	return true
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"os/exec"
+	"time"
+)
+
+// processStopTimeout 停止进程时等待其自行退出的最长时间
+const processStopTimeout = 5 * time.Second
+
+// processBackend 进程管理后端，按操作系统通过构建标签选择实现
+type processBackend interface {
+	// Prepare 在启动前设置进程属性（隐藏窗口、进程组等）
+	Prepare(cmd *exec.Cmd)
+	// Terminate 终止进程及其子进程，done 在进程退出后关闭
+	Terminate(cmd *exec.Cmd, done <-chan struct{}) error
+	// Cleanup 清理残留的矿工进程
+	Cleanup()
+}
@@ -0,0 +1,63 @@
+//go:build !windows
+
+package service
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"syscall"
+	"time"
+)
+
+// xmrigBinaryName XMRig可执行文件名
+const xmrigBinaryName = "xmrig"
+
+// embeddedExtraFiles 随可执行文件一起提取的附加文件
+var embeddedExtraFiles []string
+
+// unixBackend 基于进程组的 Unix 进程后端
+type unixBackend struct {
+	timeout time.Duration
+}
+
+func newProcessBackend() processBackend {
+	return unixBackend{timeout: processStopTimeout}
+}
+
+// Prepare 让子进程运行在独立的进程组中，便于整体发送信号
+func (unixBackend) Prepare(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// Terminate 先向进程组发送 SIGTERM，超时后发送 SIGKILL
+func (b unixBackend) Terminate(cmd *exec.Cmd, done <-chan struct{}) error {
+	if cmd == nil || cmd.Process == nil {
+		return nil
+	}
+	pgid := cmd.Process.Pid
+	if err := syscall.Kill(-pgid, syscall.SIGTERM); err != nil {
+		if errors.Is(err, syscall.ESRCH) {
+			return nil
+		}
+		return fmt.Errorf("发送SIGTERM失败: %w", err)
+	}
+	if done == nil {
+		return nil
+	}
+
+	select {
+	case <-done:
+		return nil
+	case <-time.After(b.timeout):
+	}
+
+	if err := syscall.Kill(-pgid, syscall.SIGKILL); err != nil && !errors.Is(err, syscall.ESRCH) {
+		return fmt.Errorf("发送SIGKILL失败: %w", err)
+	}
+	<-done
+	return nil
+}
+
+// Cleanup Unix下子进程均在独立进程组中，由 Terminate 负责清理
+func (unixBackend) Cleanup() {}
@@ -0,0 +1,54 @@
+//go:build windows
+
+package service
+
+import (
+	"os/exec"
+	"strconv"
+	"syscall"
+)
+
+// xmrigBinaryName XMRig可执行文件名
+const xmrigBinaryName = "xmrig.exe"
+
+// createNoWindow CREATE_NO_WINDOW
+const createNoWindow = 0x08000000
+
+// embeddedExtraFiles 随可执行文件一起提取的附加文件
+var embeddedExtraFiles = []string{"WinRing0x64.sys"}
+
+// windowsBackend 基于 taskkill 的 Windows 进程后端
+type windowsBackend struct{}
+
+func newProcessBackend() processBackend {
+	return windowsBackend{}
+}
+
+// Prepare 隐藏cmd窗口
+func (windowsBackend) Prepare(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		HideWindow:    true,
+		CreationFlags: createNoWindow,
+	}
+}
+
+// Terminate 按PID强制结束进程树
+func (b windowsBackend) Terminate(cmd *exec.Cmd, done <-chan struct{}) error {
+	if cmd == nil || cmd.Process == nil {
+		return nil
+	}
+	b.runSilent("taskkill", "/F", "/T", "/PID", strconv.Itoa(cmd.Process.Pid))
+	_ = cmd.Process.Kill()
+	return nil
+}
+
+// Cleanup 结束所有残留的 xmrig.exe
+func (b windowsBackend) Cleanup() {
+	b.runSilent("taskkill", "/F", "/IM", xmrigBinaryName)
+}
+
+func (b windowsBackend) runSilent(name string, args ...string) {
+	cmd := exec.Command(name, args...)
+	b.Prepare(cmd)
+	_ = cmd.Run()
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"fmt"
+	"go-wails/internal/models"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxProfileNameLength 配置方案名称最大字符数
+const maxProfileNameLength = 32
+
+// ListProfiles 列出所有配置方案
+func (s *ConfigService) ListProfiles() ([]models.ProfileInfo, error) {
+	settings, err := s.LoadSettings()
+	if err != nil {
+		return nil, err
+	}
+	active := settings.Active().Name
+	list := make([]models.ProfileInfo, 0, len(settings.Profiles))
+	for _, p := range settings.Profiles {
+		list = append(list, models.ProfileInfo{
+			Name:   p.Name,
+			Active: p.Name == active,
+			Pools:  len(p.Miner.Pools),
+		})
+	}
+	return list, nil
+}
+
+// ActiveProfile 返回当前配置方案名称
+func (s *ConfigService) ActiveProfile() (string, error) {
+	settings, err := s.LoadSettings()
+	if err != nil {
+		return "", err
+	}
+	return settings.Active().Name, nil
+}
+
+// LoadProfileConfig 读取指定配置方案的配置
+func (s *ConfigService) LoadProfileConfig(name string) (*models.XMRigConfig, error) {
+	settings, err := s.LoadSettings()
+	if err != nil {
+		return nil, err
+	}
+	p := settings.Profile(name)
+	if p == nil {
+		return nil, fmt.Errorf("配置方案不存在: %s", name)
+	}
+	return settings.ProfileConfig(p), nil
+}
+
+// CreateProfile 以默认配置新建配置方案
+func (s *ConfigService) CreateProfile(name string) error {
+	return s.updateSettings(func(settings *models.Settings) error {
+		name, err := newProfileName(settings, name)
+		if err != nil {
+			return err
+		}
+		settings.Profiles = append(settings.Profiles, models.Profile{
+			Name:  name,
+			Miner: models.MinerSettingsFrom(s.GetDefaultConfig()),
+		})
+		return nil
+	})
+}
+
+// CloneProfile 复制已有配置方案
+func (s *ConfigService) CloneProfile(source, name string) error {
+	return s.updateSettings(func(settings *models.Settings) error {
+		src := settings.Profile(source)
+		if src == nil {
+			return fmt.Errorf("配置方案不存在: %s", source)
+		}
+		name, err := newProfileName(settings, name)
+		if err != nil {
+			return err
+		}
+		// 通过配置结构转换复制，避免与源方案共用矿池切片
+		clone := models.MinerSettingsFrom(settings.ProfileConfig(src))
+		settings.Profiles = append(settings.Profiles, models.Profile{Name: name, Miner: clone})
+		return nil
+	})
+}
+
+// RenameProfile 重命名配置方案，当前方案与定时规则中的引用随之更新
+func (s *ConfigService) RenameProfile(oldName, newName string) error {
+	return s.updateSettings(func(settings *models.Settings) error {
+		p := settings.Profile(oldName)
+		if p == nil {
+			return fmt.Errorf("配置方案不存在: %s", oldName)
+		}
+		name, err := newProfileName(settings, newName)
+		if err != nil {
+			return err
+		}
+		if settings.ActiveProfile == oldName {
+			settings.ActiveProfile = name
+		}
+		for i := range settings.Schedule.Rules {
+			if settings.Schedule.Rules[i].Profile == oldName {
+				settings.Schedule.Rules[i].Profile = name
+			}
+		}
+		p.Name = name
+		return nil
+	})
+}
+
+// DeleteProfile 删除配置方案，当前方案、最后一个方案与定时规则引用的方案不能删除
+func (s *ConfigService) DeleteProfile(name string) error {
+	return s.updateSettings(func(settings *models.Settings) error {
+		if settings.Profile(name) == nil {
+			return fmt.Errorf("配置方案不存在: %s", name)
+		}
+		if settings.Active().Name == name {
+			return fmt.Errorf("不能删除当前使用的配置方案，请先切换到其他方案")
+		}
+		for _, r := range settings.Schedule.Rules {
+			if r.Profile == name {
+				return fmt.Errorf("定时规则 %s 正在使用该配置方案", r.Name)
+			}
+		}
+		profiles := settings.Profiles[:0]
+		for _, p := range settings.Profiles {
+			if p.Name != name {
+				profiles = append(profiles, p)
+			}
+		}
+		settings.Profiles = profiles
+		return nil
+	})
+}
+
+// SetActiveProfile 设置当前配置方案，下次启动挖矿时生效
+func (s *ConfigService) SetActiveProfile(name string) error {
+	return s.updateSettings(func(settings *models.Settings) error {
+		if settings.Profile(name) == nil {
+			return fmt.Errorf("配置方案不存在: %s", name)
+		}
+		settings.ActiveProfile = name
+		return nil
+	})
+}
+
+// updateSettings 在锁内读取、修改并保存设置
+func (s *ConfigService) updateSettings(update func(settings *models.Settings) error) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	settings, err := s.loadSettingsLocked()
+	if err != nil {
+		return err
+	}
+	if err := update(settings); err != nil {
+		return err
+	}
+	return s.saveSettingsLocked(settings)
+}
+
+// newProfileName 校验新配置方案名称：非空、长度受限、不含控制字符且不与已有方案重名
+func newProfileName(settings *models.Settings, name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("配置方案名称不能为空")
+	}
+	if utf8.RuneCountInString(name) > maxProfileNameLength {
+		return "", fmt.Errorf("配置方案名称不能超过 %d 个字符", maxProfileNameLength)
+	}
+	for _, r := range name {
+		if unicode.IsControl(r) {
+			return "", fmt.Errorf("配置方案名称不能包含控制字符")
+		}
+	}
+	if settings.Profile(name) != nil {
+		return "", fmt.Errorf("配置方案已存在: %s", name)
+	}
+	return name, nil
+}
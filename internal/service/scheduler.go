@@ -0,0 +1,269 @@
+package service
+
+import (
+	"fmt"
+	"go-wails/internal/events"
+	"go-wails/internal/models"
+	"go-wails/internal/schedule"
+	"sync"
+	"time"
+)
+
+// maxScheduleWait 两次检查之间的最长等待，系统休眠或调整时间后也能及时纠正
+const maxScheduleWait = time.Minute
+
+// scheduleRetryWait 执行规则状态失败后首次重试前的等待，之后每次加倍，最长 maxScheduleWait
+const scheduleRetryWait = 10 * time.Second
+
+// LoadSchedule 读取定时挖矿配置
+func (s *ConfigService) LoadSchedule() (models.ScheduleConfig, error) {
+	settings, err := s.LoadSettings()
+	if err != nil {
+		return models.ScheduleConfig{}, err
+	}
+	return settings.Schedule, nil
+}
+
+// SaveSchedule 校验并保存定时挖矿配置
+func (s *ConfigService) SaveSchedule(cfg models.ScheduleConfig) error {
+	if _, err := schedule.Compile(cfg); err != nil {
+		return err
+	}
+	return s.updateSettings(func(settings *models.Settings) error {
+		for _, r := range cfg.Rules {
+			if r.Profile != "" && settings.Profile(r.Profile) == nil {
+				return fmt.Errorf("规则 %s 引用的配置方案不存在: %s", r.Name, r.Profile)
+			}
+		}
+		settings.Schedule = cfg
+		return nil
+	})
+}
+
+// Scheduler 按每周时间规则开始、停止挖矿或切换配置方案与线程比例。
+// 只在规则状态变化时执行动作，用户在两次切换之间的手动操作会保留到下一次切换
+type Scheduler struct {
+	supervisor *Supervisor
+	configSvc  *ConfigService
+	clock      schedule.Clock
+	mutex      sync.Mutex
+	applied    *schedule.State
+	failures   int // 当前状态连续执行失败的次数
+	lastError  string
+	reload     chan struct{}
+	stopCh     chan struct{}
+	stopOnce   sync.Once
+}
+
+// NewScheduler 创建定时调度器，clock 为 nil 时使用系统时间
+func NewScheduler(supervisor *Supervisor, configSvc *ConfigService, clock schedule.Clock) *Scheduler {
+	if clock == nil {
+		clock = schedule.SystemClock{}
+	}
+	return &Scheduler{
+		supervisor: supervisor,
+		configSvc:  configSvc,
+		clock:      clock,
+		reload:     make(chan struct{}, 1),
+		stopCh:     make(chan struct{}),
+	}
+}
+
+// Start 启动调度
+func (s *Scheduler) Start() {
+	go s.run()
+}
+
+// Stop 停止调度，不改变挖矿状态
+func (s *Scheduler) Stop() {
+	s.stopOnce.Do(func() { close(s.stopCh) })
+}
+
+// Reload 规则变化后立即重新计算
+func (s *Scheduler) Reload() {
+	select {
+	case s.reload <- struct{}{}:
+	default:
+	}
+}
+
+// Status 返回当前生效的规则与下一次切换
+func (s *Scheduler) Status() models.ScheduleStatus {
+	cfg, err := s.configSvc.LoadSchedule()
+	if err != nil {
+		return models.ScheduleStatus{Error: err.Error()}
+	}
+	status := models.ScheduleStatus{Enabled: cfg.Enabled}
+	sched, err := schedule.Compile(cfg)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+
+	now := s.clock.Now()
+	current := sched.At(now)
+	status.Rule = current.Rule
+	status.Action = current.Action
+	status.Profile = current.Profile
+	status.MaxThreadsHint = current.MaxThreadsHint
+	if next, state, ok := sched.Next(now); ok {
+		status.Next = &next
+		status.NextRule = state.Rule
+		status.NextAction = state.Action
+		status.NextProfile = state.Profile
+	}
+	return status
+}
+
+func (s *Scheduler) run() {
+	for {
+		wait := s.tick()
+		select {
+		case <-s.stopCh:
+			return
+		case <-s.reload:
+		case <-s.clock.After(wait):
+		}
+	}
+}
+
+// tick 计算当前状态，变化时执行，返回到下一次检查的等待时间
+func (s *Scheduler) tick() time.Duration {
+	cfg, err := s.configSvc.LoadSchedule()
+	if err != nil || !cfg.Enabled {
+		s.mutex.Lock()
+		wasApplied := s.applied != nil
+		s.applied = nil
+		s.mutex.Unlock()
+		if wasApplied {
+			// 关闭定时后恢复配置方案中的线程比例
+			s.supervisor.xmrig.SetThreadsHint(0)
+		}
+		return maxScheduleWait
+	}
+	sched, err := schedule.Compile(cfg)
+	if err != nil {
+		// 同一错误只提示一次
+		if err.Error() != s.lastError {
+			s.lastError = err.Error()
+			s.notify(models.LogError, fmt.Sprintf("定时规则无效: %v", err))
+		}
+		return maxScheduleWait
+	}
+	s.lastError = ""
+
+	now := s.clock.Now()
+	state := sched.At(now)
+	s.mutex.Lock()
+	changed := s.applied == nil || !s.applied.Same(state)
+	s.mutex.Unlock()
+
+	wait := maxScheduleWait
+	if changed {
+		if err := s.apply(state); err != nil {
+			// 不记为已执行，退避后重试，否则一次失败会错过整个时间窗口
+			wait = s.retryWait()
+			s.notify(models.LogError, fmt.Sprintf("%v，%s 后重试", err, wait))
+		} else {
+			s.mutex.Lock()
+			s.applied = &state
+			s.mutex.Unlock()
+			s.failures = 0
+		}
+	}
+	if next, _, ok := sched.Next(now); ok && next.Sub(now) < wait {
+		wait = next.Sub(now)
+	}
+	return wait
+}
+
+// retryWait 返回下一次重试前的等待并累计失败次数
+func (s *Scheduler) retryWait() time.Duration {
+	wait := scheduleRetryWait
+	for i := 0; i < s.failures && wait < maxScheduleWait; i++ {
+		wait *= 2
+	}
+	if wait > maxScheduleWait {
+		wait = maxScheduleWait
+	}
+	s.failures++
+	return wait
+}
+
+// apply 执行规则状态：停止挖矿，或按需切换配置方案、线程比例后开始挖矿
+func (s *Scheduler) apply(state schedule.State) error {
+	xmrig := s.supervisor.xmrig
+	xmrig.Events().Publish(events.ScheduleChanged{
+		Rule:           state.Rule,
+		Action:         state.Action,
+		Profile:        state.Profile,
+		MaxThreadsHint: state.MaxThreadsHint,
+	})
+
+	rule := state.Rule
+	if rule == "" {
+		rule = "默认"
+	}
+	if state.Action == schedule.ActionStop {
+		xmrig.SetThreadsHint(0)
+		if xmrig.IsRunning() {
+			s.notify(models.LogInfo, fmt.Sprintf("定时规则 %s: 停止挖矿", rule))
+			if err := s.supervisor.Stop(); err != nil {
+				return fmt.Errorf("定时停止挖矿失败: %w", err)
+			}
+		}
+		return nil
+	}
+
+	restart := false
+	if state.Profile != "" {
+		active, err := s.configSvc.ActiveProfile()
+		if err != nil {
+			return fmt.Errorf("定时切换配置方案失败: %w", err)
+		}
+		if active != state.Profile {
+			if err := s.configSvc.SetActiveProfile(state.Profile); err != nil {
+				return fmt.Errorf("定时切换配置方案失败: %w", err)
+			}
+			restart = true
+		}
+	}
+	if xmrig.ThreadsHint() != state.MaxThreadsHint {
+		xmrig.SetThreadsHint(state.MaxThreadsHint)
+		restart = true
+	}
+
+	running := xmrig.IsRunning()
+	if running && !restart {
+		return nil
+	}
+	s.notify(models.LogInfo, fmt.Sprintf("定时规则 %s: 开始挖矿（配置方案 %s，线程比例 %s）", rule, orDefault(state.Profile, "当前"), threadsHintText(state.MaxThreadsHint)))
+	if running {
+		if err := s.supervisor.Stop(); err != nil {
+			return fmt.Errorf("定时重启挖矿失败: %w", err)
+		}
+	}
+	if err := s.supervisor.Start(); err != nil {
+		return fmt.Errorf("定时开始挖矿失败: %w", err)
+	}
+	return nil
+}
+
+// notify 以管理器日志的形式发布提示
+func (s *Scheduler) notify(level, message string) {
+	s.supervisor.xmrig.Notify(level, message)
+}
+
+func orDefault(v, fallback string) string {
+	if v == "" {
+		return fallback
+	}
+	return v
+}
+
+func threadsHintText(hint int) string {
+	if hint <= 0 {
+		return "按方案设置"
+	}
+	return fmt.Sprintf("%d%%", hint)
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"go-wails/internal/models"
+	"go-wails/internal/stratum"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// manualClock 由测试推进的时钟
+type manualClock struct {
+	now time.Time
+}
+
+func (c *manualClock) Now() time.Time { return c.now }
+
+func (c *manualClock) After(d time.Duration) <-chan time.Time {
+	c.now = c.now.Add(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func TestSchedulerRetriesFailedStart(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows 下停止挖矿会结束系统中所有 xmrig 进程")
+	}
+	xmrig, configSvc := newTestXMRig(t)
+	// 窗口开始时矿池不可用，之后恢复
+	var poolUp atomic.Bool
+	xmrig.selector = NewPoolSelector(func(ctx context.Context, pool models.PoolConfig) stratum.ProbeResult {
+		if !poolUp.Load() {
+			return stratum.ProbeResult{Class: stratum.FailureTCP, Error: "connection refused"}
+		}
+		return stratum.ProbeResult{OK: true}
+	}, configSvc.GetDataDir())
+	supervisor := NewSupervisor(xmrig, configSvc)
+	defer supervisor.Close()
+	defer supervisor.Stop()
+
+	err := configSvc.SaveSchedule(models.ScheduleConfig{
+		Enabled:  true,
+		Timezone: "UTC",
+		Rules:    []models.ScheduleRule{{Name: "night", Start: "22:00", End: "06:00", Action: "mine"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock := &manualClock{now: time.Date(2026, time.October, 16, 22, 0, 0, 0, time.UTC)}
+	scheduler := NewScheduler(supervisor, configSvc, clock)
+
+	// 连续失败时按退避重试，不记为已执行
+	for i, want := range []time.Duration{10 * time.Second, 20 * time.Second, 40 * time.Second, time.Minute, time.Minute} {
+		if wait := scheduler.tick(); wait != want {
+			t.Fatalf("第 %d 次失败后等待 %s，期望 %s", i+1, wait, want)
+		}
+		if scheduler.applied != nil {
+			t.Fatalf("第 %d 次启动失败后状态被记为已执行", i+1)
+		}
+		<-clock.After(want)
+	}
+
+	poolUp.Store(true)
+	if wait := scheduler.tick(); wait != maxScheduleWait {
+		t.Fatalf("启动成功后等待 %s，期望 %s", wait, maxScheduleWait)
+	}
+	if !xmrig.IsRunning() {
+		t.Fatal("矿池恢复后没有在窗口内开始挖矿")
+	}
+	if scheduler.applied == nil || scheduler.applied.Rule != "night" {
+		t.Fatalf("已执行状态得到 %+v，期望 night", scheduler.applied)
+	}
+	if scheduler.failures != 0 {
+		t.Errorf("成功后失败次数得到 %d，期望 0", scheduler.failures)
+	}
+}
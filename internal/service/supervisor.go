@@ -0,0 +1,301 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"go-wails/internal/events"
+	"go-wails/internal/models"
+	"sync"
+	"time"
+)
+
+// 重启记录的处理结果
+const (
+	RestartScheduled = "scheduled"
+	RestartDone      = "restarted"
+	RestartFailed    = "failed"
+	RestartGaveUp    = "gave-up"
+	RestartDisabled  = "disabled"
+	RestartCancelled = "cancelled"
+)
+
+// 自动重启默认策略
+const (
+	defaultMaxRestarts    = 5
+	defaultRestartWindow  = 10 * time.Minute
+	defaultInitialBackoff = 5 * time.Second
+	defaultMaxBackoff     = 5 * time.Minute
+	// stableRunDuration 运行超过该时长后视为恢复稳定，退避重新计算
+	stableRunDuration = 2 * time.Minute
+	// maxRestartHistory 保留的重启记录条数
+	maxRestartHistory = 100
+)
+
+// Supervisor 监督XMRig进程，区分用户停止与异常退出，异常退出时按指数退避自动重启
+type Supervisor struct {
+	xmrig     *XMRigService
+	configSvc *ConfigService
+	mutex     sync.Mutex
+	history   []models.RestartRecord
+	restarts  []time.Time
+	attempt   int
+	timer     *time.Timer
+	closed    bool
+	// generation 用户每次启动或停止时加一，进行中的重启据此判断是否已被用户操作取代
+	generation int
+}
+
+// NewSupervisor 创建监督器。进程退出直接由XMRig服务同步通知，
+// 不经过事件总线，大量日志输出时也不会漏掉异常退出
+func NewSupervisor(xmrig *XMRigService, configSvc *ConfigService) *Supervisor {
+	s := &Supervisor{
+		xmrig:     xmrig,
+		configSvc: configSvc,
+	}
+	xmrig.OnExit(s.handleExit)
+	return s
+}
+
+// Start 用户启动挖矿，重置退避状态
+func (s *Supervisor) Start() error {
+	s.mutex.Lock()
+	s.stopTimer()
+	s.generation++
+	s.attempt = 0
+	s.restarts = nil
+	s.mutex.Unlock()
+	return s.xmrig.Start()
+}
+
+// Stop 用户停止挖矿，取消待执行的重启，正在进行的重启完成后也会被撤销
+func (s *Supervisor) Stop() error {
+	s.mutex.Lock()
+	s.stopTimer()
+	s.generation++
+	s.mutex.Unlock()
+	return s.xmrig.Stop()
+}
+
+// Restart 受控重启：停止当前进程，以 pool 为首选矿池重新启动（pool 为空时按策略选择）。
+// 启动失败时按异常退出处理，由自动重启继续尝试，不会让挖矿就此停下；
+// 期间用户停止了挖矿时不再启动，返回 ErrStartCancelled
+func (s *Supervisor) Restart(pool string) error {
+	s.mutex.Lock()
+	s.stopTimer()
+	generation := s.generation
+	s.mutex.Unlock()
+
+	if err := s.xmrig.Stop(); err != nil {
+		return err
+	}
+	s.xmrig.mutex.Lock()
+	s.xmrig.nextPool = pool
+	s.xmrig.mutex.Unlock()
+
+	cancelled, err := s.startAs(generation)
+	if cancelled {
+		return ErrStartCancelled
+	}
+	if err != nil {
+		s.onUnexpectedExit(events.Stopped{ExitCode: -1, Reason: err.Error(), Time: time.Now()})
+		return err
+	}
+	return nil
+}
+
+// Close 取消待执行的重启，之后的退出不再处理
+func (s *Supervisor) Close() {
+	s.mutex.Lock()
+	s.stopTimer()
+	s.closed = true
+	s.mutex.Unlock()
+}
+
+// History 返回重启记录，最新的在最后
+func (s *Supervisor) History() []models.RestartRecord {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	history := make([]models.RestartRecord, len(s.history))
+	copy(history, s.history)
+	return history
+}
+
+// handleExit 处理进程退出
+func (s *Supervisor) handleExit(ev events.Stopped) {
+	s.mutex.Lock()
+	closed := s.closed
+	s.mutex.Unlock()
+	if !closed && !ev.Expected {
+		s.onUnexpectedExit(ev)
+	}
+}
+
+// onUnexpectedExit 记录异常退出并按策略安排重启
+func (s *Supervisor) onUnexpectedExit(ev events.Stopped) {
+	policy := s.loadPolicy()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	record := models.RestartRecord{
+		Time:     ev.Time.Unix(),
+		ExitCode: ev.ExitCode,
+		Reason:   ev.Reason,
+	}
+
+	if policy.Disabled {
+		record.Action = RestartDisabled
+		s.record(record)
+		return
+	}
+
+	if !ev.Started.IsZero() && ev.Time.Sub(ev.Started) >= stableRunDuration {
+		s.attempt = 0
+	}
+
+	// 清理窗口期之外的重启
+	window := secondsOr(policy.WindowSeconds, defaultRestartWindow)
+	recent := s.restarts[:0]
+	for _, t := range s.restarts {
+		if ev.Time.Sub(t) < window {
+			recent = append(recent, t)
+		}
+	}
+	s.restarts = recent
+
+	maxRestarts := policy.MaxRestarts
+	if maxRestarts <= 0 {
+		maxRestarts = defaultMaxRestarts
+	}
+	if len(s.restarts) >= maxRestarts {
+		record.Action = RestartGaveUp
+		record.Error = fmt.Sprintf("%s 内已重启 %d 次，停止自动重启", window, len(s.restarts))
+		s.record(record)
+		s.notify(models.LogError, record.Error)
+		return
+	}
+
+	delay := s.backoff(policy)
+	s.attempt++
+	s.restarts = append(s.restarts, ev.Time)
+
+	record.Attempt = s.attempt
+	record.Delay = int(delay / time.Second)
+	record.Action = RestartScheduled
+	s.record(record)
+	s.notify(models.LogWarning, fmt.Sprintf("矿工进程异常退出（%s），%s 后进行第 %d 次重启", ev.Reason, delay, s.attempt))
+
+	s.stopTimer()
+	attempt := s.attempt
+	s.timer = time.AfterFunc(delay, func() { s.restart(attempt) })
+}
+
+// restart 执行计划中的重启
+func (s *Supervisor) restart(attempt int) {
+	s.mutex.Lock()
+	if s.timer == nil || s.attempt != attempt {
+		// 已被用户操作取消
+		s.mutex.Unlock()
+		return
+	}
+	s.timer = nil
+	generation := s.generation
+	s.mutex.Unlock()
+
+	if s.xmrig.IsRunning() {
+		return
+	}
+
+	record := models.RestartRecord{
+		Time:    time.Now().Unix(),
+		Attempt: attempt,
+		Action:  RestartDone,
+	}
+	cancelled, err := s.startAs(generation)
+
+	s.mutex.Lock()
+	switch {
+	case cancelled:
+		record.Action = RestartCancelled
+	case err != nil:
+		record.Action = RestartFailed
+		record.Error = err.Error()
+	}
+	s.record(record)
+	s.mutex.Unlock()
+
+	if cancelled {
+		return
+	}
+	if err != nil {
+		s.notify(models.LogError, fmt.Sprintf("第 %d 次重启失败: %v", attempt, err))
+		// 启动失败同样视为异常退出，继续按退避策略重试
+		s.onUnexpectedExit(events.Stopped{ExitCode: -1, Reason: err.Error(), Time: time.Now()})
+	}
+}
+
+// startAs 代表 generation 时的用户意图启动挖矿。期间用户停止或重新启动了挖矿时
+// cancelled 为 true，不能当作异常退出继续重试；此时若已启动则撤销
+func (s *Supervisor) startAs(generation int) (cancelled bool, err error) {
+	err = s.xmrig.Start()
+
+	s.mutex.Lock()
+	cancelled = s.generation != generation || s.closed || errors.Is(err, ErrStartCancelled)
+	s.mutex.Unlock()
+	if cancelled && err == nil {
+		// 用户的停止早于本次启动生效，撤销刚启动的进程
+		_ = s.xmrig.Stop()
+	}
+	return cancelled, err
+}
+
+// backoff 计算本次重启前的等待时间
+func (s *Supervisor) backoff(policy models.SupervisorConfig) time.Duration {
+	delay := secondsOr(policy.InitialBackoff, defaultInitialBackoff)
+	maxDelay := secondsOr(policy.MaxBackoff, defaultMaxBackoff)
+	for i := 0; i < s.attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay
+}
+
+// loadPolicy 读取当前的重启策略
+func (s *Supervisor) loadPolicy() models.SupervisorConfig {
+	cfg, err := s.configSvc.LoadConfig()
+	if err != nil {
+		return models.SupervisorConfig{}
+	}
+	return cfg.Manager.Supervisor
+}
+
+// record 追加重启记录，调用方需持有锁
+func (s *Supervisor) record(r models.RestartRecord) {
+	if len(s.history) >= maxRestartHistory {
+		s.history = s.history[1:]
+	}
+	s.history = append(s.history, r)
+}
+
+// notify 以管理器日志的形式发布提示
+func (s *Supervisor) notify(level, message string) {
+	s.xmrig.Notify(level, message)
+}
+
+// stopTimer 取消待执行的重启，调用方需持有锁
+func (s *Supervisor) stopTimer() {
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+}
+
+// secondsOr 将秒数转换为时长，非正数时返回默认值
+func secondsOr(seconds int, fallback time.Duration) time.Duration {
+	if seconds <= 0 {
+		return fallback
+	}
+	return time.Duration(seconds) * time.Second
+}
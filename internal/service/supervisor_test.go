@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"go-wails/internal/events"
+	"go-wails/internal/models"
+	"go-wails/internal/stratum"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// newTestXMRig 在临时数据目录中创建XMRig服务，矿工程序为不会被执行的脚本
+func newTestXMRig(t *testing.T) (*XMRigService, *ConfigService) {
+	t.Helper()
+	dir := t.TempDir()
+	configSvc := NewConfigServiceAt(dir)
+	settings, err := configSvc.LoadSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	exe := filepath.Join(dir, "fake-xmrig")
+	if err := os.WriteFile(exe, []byte("#!/bin/sh\nexec sleep 30\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	digest, err := fileSHA256(exe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := settings.Config()
+	cfg.Pools = []models.PoolConfig{{URL: "stratum+tcp://127.0.0.1:1", User: DefaultWalletAddress, Enabled: true}}
+	settings.Apply(cfg)
+	settings.Manager.Binary = models.BinaryConfig{Provider: BinaryProviderPath, Path: exe, SHA256: digest}
+	settings.Manager.Supervisor.InitialBackoff = 1
+	if err := configSvc.SaveSettings(settings); err != nil {
+		t.Fatal(err)
+	}
+	return NewXMRigService(configSvc), configSvc
+}
+
+func TestSupervisorStopDuringRestart(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows 下停止挖矿会结束系统中所有 xmrig 进程")
+	}
+	xmrig, configSvc := newTestXMRig(t)
+	// 探测矿池时阻塞，模拟重启正在准备时用户点击停止
+	probing := make(chan struct{}, 16)
+	release := make(chan struct{})
+	xmrig.selector = NewPoolSelector(func(ctx context.Context, pool models.PoolConfig) stratum.ProbeResult {
+		probing <- struct{}{}
+		<-release
+		return stratum.ProbeResult{OK: true}
+	}, configSvc.GetDataDir())
+	supervisor := NewSupervisor(xmrig, configSvc)
+	defer supervisor.Close()
+
+	supervisor.handleExit(events.Stopped{ExitCode: 1, Reason: "crash", Time: time.Now()})
+	select {
+	case <-probing:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("计划中的重启没有开始: %+v", supervisor.History())
+	}
+	if err := supervisor.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	close(release)
+
+	var history []models.RestartRecord
+	for deadline := time.Now().Add(5 * time.Second); ; {
+		history = supervisor.History()
+		if len(history) > 0 && history[len(history)-1].Action != RestartScheduled {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("重启没有结束: %+v", history)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if len(history) != 2 || history[1].Action != RestartCancelled {
+		t.Fatalf("重启记录得到 %+v，期望 scheduled 后为 cancelled", history)
+	}
+	if xmrig.IsRunning() {
+		t.Error("用户停止后矿工仍在运行")
+	}
+	supervisor.mutex.Lock()
+	timer := supervisor.timer
+	supervisor.mutex.Unlock()
+	if timer != nil {
+		t.Error("用户停止后又安排了重启")
+	}
+}
+
+func TestSupervisorRestartFailureSchedulesRetry(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows 下停止挖矿会结束系统中所有 xmrig 进程")
+	}
+	xmrig, configSvc := newTestXMRig(t)
+	// 目标矿池与其他矿池都不可用
+	xmrig.selector = NewPoolSelector(func(ctx context.Context, pool models.PoolConfig) stratum.ProbeResult {
+		return stratum.ProbeResult{Class: stratum.FailureTCP, Error: "connection refused"}
+	}, configSvc.GetDataDir())
+	supervisor := NewSupervisor(xmrig, configSvc)
+	defer supervisor.Close()
+
+	if err := supervisor.Restart(""); err == nil {
+		t.Fatal("没有可用矿池时重启应当失败")
+	}
+	history := supervisor.History()
+	if len(history) != 1 || history[0].Action != RestartScheduled {
+		t.Fatalf("重启记录得到 %+v，期望安排自动重启", history)
+	}
+	supervisor.mutex.Lock()
+	timer := supervisor.timer
+	supervisor.mutex.Unlock()
+	if timer == nil {
+		t.Error("重启失败后没有安排重试，挖矿会一直停着")
+	}
+}
+
+// blockingBackend 在终止进程前等待测试放行
+type blockingBackend struct {
+	processBackend
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingBackend) Terminate(cmd *exec.Cmd, done <-chan struct{}) error {
+	close(b.entered)
+	<-b.release
+	return b.processBackend.Terminate(cmd, done)
+}
+
+func TestXMRigStopDoesNotHoldLock(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows 下停止挖矿会结束系统中所有 xmrig 进程")
+	}
+	xmrig, configSvc := newTestXMRig(t)
+	xmrig.selector = NewPoolSelector(func(ctx context.Context, pool models.PoolConfig) stratum.ProbeResult {
+		return stratum.ProbeResult{OK: true}
+	}, configSvc.GetDataDir())
+	backend := &blockingBackend{processBackend: xmrig.backend, entered: make(chan struct{}), release: make(chan struct{})}
+	xmrig.backend = backend
+	if err := xmrig.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	stopped := make(chan error, 1)
+	go func() { stopped <- xmrig.Stop() }()
+	<-backend.entered
+
+	// 终止进程期间仍可查询状态、写入日志
+	queried := make(chan struct{})
+	go func() {
+		xmrig.IsRunning()
+		xmrig.Notify(models.LogInfo, "停止中")
+		close(queried)
+	}()
+	select {
+	case <-queried:
+	case <-time.After(5 * time.Second):
+		close(backend.release)
+		t.Fatal("终止进程时持有锁，状态查询被阻塞")
+	}
+	// 停止完成前不允许重新启动，否则清理残留进程时可能结束新进程
+	if err := xmrig.Start(); err == nil {
+		t.Error("停止过程中重新启动应当失败")
+	}
+
+	close(backend.release)
+	if err := <-stopped; err != nil {
+		t.Fatal(err)
+	}
+	if xmrig.IsRunning() {
+		t.Error("停止后仍在运行")
+	}
+	if xmrig.stopping != 0 {
+		t.Errorf("停止后 stopping 得到 %d，期望 0", xmrig.stopping)
+	}
+}
@@ -3,173 +3,154 @@ package service
 import (
 	"bufio"
 	"context"
-	"crypto/tls"
 	"embed"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"go-wails/internal/events"
+	"go-wails/internal/logfile"
 	"go-wails/internal/models"
+	"go-wails/internal/stratum"
+	"go-wails/internal/sysinfo"
+	"go-wails/internal/xmrigapi"
+	"go-wails/internal/xmriglog"
 	"io"
-	"net"
-	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"runtime"
-	"strconv"
 	"strings"
 	"sync"
-	"syscall"
 	"time"
-
-	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
 //go:embed xmrig-embedded/*
 var embeddedXMRig embed.FS
 
+// ErrStartCancelled 启动准备期间用户停止了挖矿
+var ErrStartCancelled = errors.New("启动已取消")
+
 // XMRigService XMRig服务
 type XMRigService struct {
 	cmd           *exec.Cmd
+	done          chan struct{}
+	backend       processBackend
 	isRunning     bool
+	starting      bool // 正在准备启动（校验程序、探测矿池），此时不持锁
+	stopping      int  // 正在进行的 Stop 数量，终止进程时不持锁
+	stopRequested bool
 	mutex         sync.RWMutex
-	ctx           context.Context
+	bus           *events.Bus
 	configSvc     *ConfigService
 	startTime     time.Time
-	logBuffer     []string
+	logBuffer     []models.LogRecord
 	maxLogLines   int
+	logSeq        uint64 // 最近一条日志的序号
 	poolConnected bool
+	invalidShares uint64
+	currentPool   string
+	nextPool      string // 下次启动时优先使用的矿池，由切换矿池设置
+	threadsHint   int    // 运行时线程比例覆盖，0 为使用配置方案设置
+	selector      *PoolSelector
+	sessionLog    *logfile.Store
+	exitHandlers  []func(events.Stopped)
 }
 
 // NewXMRigService 创建XMRig服务
-func NewXMRigService() *XMRigService {
-	return &XMRigService{
-		configSvc:   NewConfigService(),
-		maxLogLines: 500,
-		logBuffer:   make([]string, 0, 500),
-	}
+func NewXMRigService(configSvc *ConfigService) *XMRigService {
+	s := &XMRigService{
+		configSvc:   configSvc,
+		backend:     newProcessBackend(),
+		bus:         events.NewBus(),
+		maxLogLines: 2000,
+		logBuffer:   make([]models.LogRecord, 0, 2000),
+		sessionLog:  logfile.NewStore(filepath.Join(configSvc.GetDataDir(), "logs")),
+	}
+	s.selector = NewPoolSelector(s.probePool, configSvc.GetDataDir())
+	return s
 }
 
-// SetContext 设置上下文
-func (s *XMRigService) SetContext(ctx context.Context) {
-	s.ctx = ctx
+// OnExit 注册进程退出处理函数，在进程退出后同步调用。
+// 事件总线在订阅者缓冲区满时会丢弃事件，自动重启等不能漏掉退出的逻辑应使用此方法
+func (s *XMRigService) OnExit(handler func(events.Stopped)) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.exitHandlers = append(s.exitHandlers, handler)
 }
 
-func (s *XMRigService) runSilent(name string, args ...string) {
-	cmd := exec.Command(name, args...)
-	cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true, CreationFlags: 0x08000000}
-	_ = cmd.Run()
+// Events 返回事件总线，供界面、命令行等订阅
+func (s *XMRigService) Events() *events.Bus {
+	return s.bus
 }
 
-// getXMRigExecutable 获取XMRig可执行文件路径
-func (s *XMRigService) getXMRigExecutable() (string, error) {
-	arch := runtime.GOARCH
-	var embeddedPath string
-
-	if arch == "arm64" {
-		embeddedPath = "xmrig-embedded/xmrig-windows-arm64/xmrig.exe"
-	} else {
-		embeddedPath = "xmrig-embedded/xmrig-windows-amd64/xmrig.exe"
-	}
-
-	// 创建临时目录
-	tempDir := filepath.Join(os.TempDir(), "xmrig-runtime")
-	if err := os.MkdirAll(tempDir, 0755); err != nil {
-		return "", fmt.Errorf("创建临时目录失败: %w", err)
-	}
-
-	// 提取 xmrig.exe
-	xmrigPath := filepath.Join(tempDir, "xmrig.exe")
-	if err := s.extractEmbeddedFile(embeddedPath, xmrigPath); err != nil {
-		return "", err
-	}
-
-	// 提取 WinRing0x64.sys (必需的驱动文件)
-	var driverPath string
-	if arch == "arm64" {
-		driverPath = "xmrig-embedded/xmrig-windows-arm64/WinRing0x64.sys"
-	} else {
-		driverPath = "xmrig-embedded/xmrig-windows-amd64/WinRing0x64.sys"
+// getXMRigExecutable 获取XMRig可执行文件路径并校验其摘要，任何运行矿工程序的地方都必须经过这里。
+// 校验失败时仍返回提供者与路径，供界面显示
+func (s *XMRigService) getXMRigExecutable() (MinerBinaryProvider, string, error) {
+	provider, path, err := s.resolveExecutable()
+	if err != nil {
+		return provider, path, err
 	}
-	driverDest := filepath.Join(tempDir, "WinRing0x64.sys")
-	if err := s.extractEmbeddedFile(driverPath, driverDest); err != nil {
-		// 驱动文件不是必需的，忽略错误
-		fmt.Printf("警告: 无法提取驱动文件: %v\n", err)
+	if err := provider.Manifest().Verify(); err != nil {
+		return provider, path, err
 	}
-
-	return xmrigPath, nil
+	return provider, path, nil
 }
 
-// extractEmbeddedFile 从嵌入的文件系统中提取文件
-func (s *XMRigService) extractEmbeddedFile(embeddedPath, destPath string) error {
-	// 如果文件已存在且大小正确，跳过提取
-	if info, err := os.Stat(destPath); err == nil {
-		// 检查嵌入文件的大小
-		embeddedData, err := embeddedXMRig.ReadFile(embeddedPath)
-		if err == nil && info.Size() == int64(len(embeddedData)) {
-			return nil // 文件已存在且大小正确
-		}
+// resolveExecutable 按配置的来源解析XMRig可执行文件
+func (s *XMRigService) resolveExecutable() (MinerBinaryProvider, string, error) {
+	var binaryCfg models.BinaryConfig
+	if cfg, err := s.configSvc.LoadConfig(); err == nil {
+		binaryCfg = cfg.Manager.Binary
 	}
 
-	// 读取嵌入的文件
-	data, err := embeddedXMRig.ReadFile(embeddedPath)
+	provider, err := NewMinerBinaryProvider(binaryCfg, s.configSvc.GetRuntimeDir(), s.Notify)
 	if err != nil {
-		return fmt.Errorf("读取嵌入文件失败 %s: %w", embeddedPath, err)
+		return nil, "", err
 	}
-
-	// 写入到目标路径
-	if err := os.WriteFile(destPath, data, 0755); err != nil {
-		return fmt.Errorf("写入文件失败 %s: %w", destPath, err)
+	path, err := provider.Resolve()
+	if err != nil {
+		return provider, "", err
 	}
-
-	return nil
+	return provider, path, nil
 }
 
-// Start 启动挖矿
+// Start 启动挖矿。校验程序、读取配置与探测矿池较慢，在不持锁时进行，
+// 期间状态查询不受影响，Stop 可以取消这次启动
 func (s *XMRigService) Start() error {
 	s.mutex.Lock()
-	defer s.mutex.Unlock()
-
-	if s.isRunning {
+	if s.isRunning || s.starting {
+		s.mutex.Unlock()
 		return fmt.Errorf("挖矿程序已在运行中")
 	}
+	if s.stopping > 0 {
+		// 停止过程中的清理可能结束新启动的进程
+		s.mutex.Unlock()
+		return fmt.Errorf("正在停止挖矿，请稍后再试")
+	}
+	s.starting = true
+	s.stopRequested = false
+	nextPool := s.nextPool
+	s.nextPool = ""
+	s.mutex.Unlock()
+
+	exePath, settings, cfg, chosen, err := s.prepareStart(nextPool)
 
-	exePath, err := s.getXMRigExecutable()
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.starting = false
 	if err != nil {
 		return err
 	}
-
-	cfg, err := s.configSvc.LoadConfig()
-	if err == nil && len(cfg.Pools) > 0 {
-		// 选择第一个可用矿池，并重排到首位
-		chosen := -1
-		for i, p := range cfg.Pools {
-			if !p.Enabled {
-				continue
-			}
-			if s.isPoolReachable(p.URL) {
-				chosen = i
-				break
-			}
-		}
-		if chosen == -1 {
-			return fmt.Errorf("没有可用的矿池，请检查配置是否正确")
-		}
-		if chosen != 0 {
-			pools := make([]models.PoolConfig, 0, len(cfg.Pools))
-			pools = append(pools, cfg.Pools[chosen])
-			for i, p := range cfg.Pools {
-				if i == chosen {
-					continue
-				}
-				pools = append(pools, p)
-			}
-			cfg.Pools = pools
-			// 保存调整后的运行时配置
-			_ = s.configSvc.SaveConfig(cfg)
-		}
+	if s.stopRequested {
+		return ErrStartCancelled
 	}
 
-	configPath := s.configSvc.GetConfigPath()
+	// 选中的矿池只在渲染出的 XMRig 配置中排到首位，不改动用户设置
+	configPath, err := s.configSvc.WriteRuntimeConfig(settings, RuntimeOptions{
+		PreferredPool:  chosen,
+		MaxThreadsHint: s.threadsHint,
+	})
+	if err != nil {
+		return err
+	}
 	absConfigPath, err := filepath.Abs(configPath)
 	if err != nil {
 		return fmt.Errorf("获取配置文件路径失败: %w", err)
@@ -177,93 +158,290 @@ func (s *XMRigService) Start() error {
 
 	s.cmd = exec.Command(exePath, "--config", absConfigPath)
 	s.cmd.Dir = filepath.Dir(exePath)
-	// Windows下隐藏cmd窗口
-	s.cmd.SysProcAttr = &syscall.SysProcAttr{
-		HideWindow:    true,
-		CreationFlags: 0x08000000, // CREATE_NO_WINDOW
-	}
+	s.backend.Prepare(s.cmd)
 
-	// 捕获标准输出和错误输出
-	stdout, err := s.cmd.StdoutPipe()
+	// 捕获标准输出和错误输出。使用独立的管道而不是 StdoutPipe：
+	// 进程退出时 Wait 会关闭 StdoutPipe，尚未读取的最后几行（往往是崩溃原因）会丢失
+	stdout, stdoutW, err := os.Pipe()
 	if err != nil {
 		return fmt.Errorf("创建stdout管道失败: %w", err)
 	}
-
-	stderr, err := s.cmd.StderrPipe()
+	stderr, stderrW, err := os.Pipe()
 	if err != nil {
+		stdout.Close()
+		stdoutW.Close()
 		return fmt.Errorf("创建stderr管道失败: %w", err)
 	}
+	s.cmd.Stdout = stdoutW
+	s.cmd.Stderr = stderrW
 
-	if err := s.cmd.Start(); err != nil {
+	err = s.cmd.Start()
+	// 子进程已持有写端，父进程关闭后读端会在子进程退出时读到 EOF
+	stdoutW.Close()
+	stderrW.Close()
+	if err != nil {
+		stdout.Close()
+		stderr.Close()
 		return fmt.Errorf("启动XMRig失败: %w", err)
 	}
 
 	s.isRunning = true
+	s.stopRequested = false
+	s.poolConnected = false
+	s.invalidShares = 0
 	s.startTime = time.Now()
-	s.logBuffer = make([]string, 0, s.maxLogLines)
+	s.logBuffer = make([]models.LogRecord, 0, s.maxLogLines)
+	s.done = make(chan struct{})
+	s.beginSessionLog(cfg.Manager.Logs)
+	for _, w := range ConfigWarnings(cfg) {
+		s.logLocked(managerRecord(models.LogWarning, fmt.Sprintf("警告: %s: %s", w.Field, w.Message)))
+	}
+
+	s.currentPool = cfg.Pools[chosen].URL
+	s.bus.Publish(events.Started{PID: s.cmd.Process.Pid, Pool: s.currentPool, Time: s.startTime})
+	s.bus.Publish(events.StatusChanged{Running: true})
 
 	// 异步读取输出
 	go s.readOutput(stdout, "stdout")
 	go s.readOutput(stderr, "stderr")
 
 	// 监控进程
-	go s.monitorProcess()
+	go s.monitorProcess(s.cmd, s.done)
 
 	return nil
 }
 
-// readOutput 读取输出
-func (s *XMRigService) readOutput(reader io.Reader, source string) {
+// prepareStart 在不持锁时校验程序与配置，并选择本次使用的矿池
+func (s *XMRigService) prepareStart(nextPool string) (string, *models.Settings, *models.XMRigConfig, int, error) {
+	_, exePath, err := s.getXMRigExecutable()
+	if err != nil {
+		return "", nil, nil, 0, err
+	}
+
+	settings, err := s.configSvc.LoadSettings()
+	if err != nil {
+		return "", nil, nil, 0, err
+	}
+	cfg := settings.Config()
+	// 设置文件可能被手工修改，启动前再校验一次
+	if errs := ValidateConfig(cfg); len(errs) > 0 {
+		return "", nil, nil, 0, &ConfigValidationError{Errors: errs}
+	}
+
+	// 切换矿池时指定的矿池优先，否则按策略选择能登录并下发任务的矿池
+	chosen := poolIndex(cfg.Pools, nextPool)
+	if chosen == -1 {
+		chosen, _, err = s.selector.Select(cfg.Pools, cfg.Manager.PoolStrategy, "")
+		if err != nil {
+			return "", nil, nil, 0, err
+		}
+	}
+	return exePath, settings, cfg, chosen, nil
+}
+
+// SetThreadsHint 设置运行时线程比例覆盖，下次启动时生效，0 为使用配置方案设置
+func (s *XMRigService) SetThreadsHint(hint int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.threadsHint = hint
+}
+
+// ThreadsHint 返回运行时线程比例覆盖
+func (s *XMRigService) ThreadsHint() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.threadsHint
+}
+
+// PID 返回运行中矿工进程的 PID，未运行时返回 0
+func (s *XMRigService) PID() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if !s.isRunning || s.cmd == nil || s.cmd.Process == nil {
+		return 0
+	}
+	return s.cmd.Process.Pid
+}
+
+// poolIndex 返回指定地址的已启用矿池下标，不存在时返回 -1
+func poolIndex(pools []models.PoolConfig, url string) int {
+	if url == "" {
+		return -1
+	}
+	for i, p := range pools {
+		if p.URL == url && p.Enabled {
+			return i
+		}
+	}
+	return -1
+}
+
+// readOutput 读取输出，逐行解析后更新矿池连接状态与无效份额计数，读完后关闭管道
+func (s *XMRigService) readOutput(reader io.ReadCloser, source string) {
+	defer reader.Close()
 	scanner := bufio.NewScanner(reader)
 	for scanner.Scan() {
-		line := scanner.Text()
-		s.addLog(line)
+		record := xmriglog.Parse(source, scanner.Text(), time.Now())
+		s.mutex.Lock()
+		record = s.appendLogLocked(record)
+		s.mutex.Unlock()
 
-		lower := strings.ToLower(line)
-		if strings.Contains(lower, "new job") || strings.Contains(lower, "connected") || strings.Contains(lower, "login succeeded") {
+		if connected, ok := xmriglog.PoolState(record); ok {
+			s.setPoolConnected(connected)
+		}
+		if xmriglog.InvalidShare(record) {
 			s.mutex.Lock()
-			s.poolConnected = true
+			s.invalidShares++
 			s.mutex.Unlock()
 		}
-		if strings.Contains(lower, "failed") || strings.Contains(lower, "error") || strings.Contains(lower, "banned") || strings.Contains(lower, "access denied") || strings.Contains(lower, "timeout") {
-			if strings.Contains(lower, "pool") || strings.Contains(lower, "net") {
-				s.mutex.Lock()
-				s.poolConnected = false
-				s.mutex.Unlock()
-			}
-		}
 
-		if s.ctx != nil {
-			wailsruntime.EventsEmit(s.ctx, "miner:log", map[string]string{
-				"source": source,
-				"line":   line,
-				"time":   time.Now().Format("15:04:05"),
-			})
-		}
+		s.publishLog(record)
 	}
 }
 
-// addLog 添加日志
-func (s *XMRigService) addLog(line string) {
+// setPoolConnected 更新矿池连接状态，发生变化时发布事件
+func (s *XMRigService) setPoolConnected(connected bool) {
 	s.mutex.Lock()
-	defer s.mutex.Unlock()
+	changed := s.poolConnected != connected
+	s.poolConnected = connected
+	running := s.isRunning
+	pool := s.currentPool
+	s.mutex.Unlock()
+
+	if changed && connected {
+		s.selector.MarkGood(pool)
+	}
+
+	if changed {
+		s.bus.Publish(events.StatusChanged{Running: running, Connected: connected})
+	}
+}
+
+// Notify 记录并发布一条管理器提示，level 为 models.LogInfo 等日志级别
+func (s *XMRigService) Notify(level, message string) {
+	s.mutex.Lock()
+	record := s.appendLogLocked(managerRecord(level, message))
+	s.mutex.Unlock()
+	s.publishLog(record)
+}
+
+// logLocked 在持锁时记录并发布日志
+func (s *XMRigService) logLocked(record models.LogRecord) {
+	s.publishLog(s.appendLogLocked(record))
+}
 
+// appendLogLocked 分配序号后添加日志并写入会话日志文件，调用方持有锁
+func (s *XMRigService) appendLogLocked(record models.LogRecord) models.LogRecord {
+	s.logSeq++
+	record.Seq = s.logSeq
 	if len(s.logBuffer) >= s.maxLogLines {
 		s.logBuffer = s.logBuffer[1:]
 	}
-	s.logBuffer = append(s.logBuffer, line)
+	s.logBuffer = append(s.logBuffer, record)
+	_ = s.sessionLog.WriteLine(fileLine(record), time.Now())
+	return record
+}
+
+// beginSessionLog 为本次启动打开新的日志文件，调用方持有锁
+func (s *XMRigService) beginSessionLog(cfg models.LogsConfig) {
+	if cfg.Disabled {
+		_ = s.sessionLog.Close()
+		return
+	}
+	s.sessionLog.SetPolicy(logfile.Policy{
+		MaxSize:       int64(cfg.MaxSizeMB) << 20,
+		MaxAge:        time.Duration(cfg.MaxAgeHours) * time.Hour,
+		RetentionDays: cfg.RetentionDays,
+		MaxFiles:      cfg.MaxFiles,
+	})
+	if _, err := s.sessionLog.Begin(s.startTime); err != nil {
+		s.logLocked(managerRecord(models.LogWarning, fmt.Sprintf("警告: 无法写入日志文件: %v", err)))
+	}
+}
+
+// fileLine 返回写入日志文件的行，没有 XMRig 时间戳的行补上时间与来源
+func fileLine(r models.LogRecord) string {
+	if r.Source == "stdout" && r.Tag != "" {
+		return r.Line
+	}
+	return fmt.Sprintf("[%s]  %-8s %s", time.UnixMilli(r.Time).Format("2006-01-02 15:04:05.000"), r.Source, r.Line)
+}
+
+// LogSessions 列出日志文件中保存的历次运行
+func (s *XMRigService) LogSessions() ([]models.LogSession, error) {
+	return s.sessionLog.Sessions()
+}
+
+// ReadLogSession 读取一次运行的日志，tail 大于 0 时只返回最后 tail 行
+func (s *XMRigService) ReadLogSession(id string, tail int) ([]string, error) {
+	return s.sessionLog.Read(id, tail)
+}
+
+// Close 关闭日志文件并等待轮转出的分段压缩完成，应在停止挖矿后调用
+func (s *XMRigService) Close() error {
+	err := s.sessionLog.Close()
+	s.sessionLog.Wait()
+	return err
+}
+
+// publishLog 发布日志事件
+func (s *XMRigService) publishLog(record models.LogRecord) {
+	s.bus.Publish(events.LogLine{
+		Source: record.Source,
+		Line:   record.Line,
+		Time:   time.UnixMilli(record.Time).Format("15:04:05"),
+		Record: &record,
+	})
+}
+
+// managerRecord 将管理器提示转换为结构化日志
+func managerRecord(level, message string) models.LogRecord {
+	return models.LogRecord{
+		Time:    time.Now().UnixMilli(),
+		Source:  "manager",
+		Tag:     "manager",
+		Level:   level,
+		Message: message,
+		Line:    message,
+	}
 }
 
 // monitorProcess 监控进程
-func (s *XMRigService) monitorProcess() {
-	if s.cmd != nil && s.cmd.Process != nil {
-		s.cmd.Wait()
+func (s *XMRigService) monitorProcess(cmd *exec.Cmd, done chan struct{}) {
+	if cmd != nil && cmd.Process != nil {
+		waitErr := cmd.Wait()
+		// 先通知进程已退出，唤醒正在等待的 Stop
+		close(done)
 		s.mutex.Lock()
-		s.isRunning = false
+		current := s.cmd == cmd
+		expected := s.stopRequested || !current
+		if current {
+			s.isRunning = false
+			s.poolConnected = false
+		}
+		started := s.startTime
+		handlers := s.exitHandlers
 		s.mutex.Unlock()
 
-		if s.ctx != nil {
-			wailsruntime.EventsEmit(s.ctx, "miner:stopped", nil)
+		stopped := events.Stopped{
+			ExitCode: -1,
+			Expected: expected,
+			Started:  started,
+			Time:     time.Now(),
+		}
+		if cmd.ProcessState != nil {
+			stopped.ExitCode = cmd.ProcessState.ExitCode()
+			stopped.Reason = cmd.ProcessState.String()
+		}
+		if waitErr != nil {
+			stopped.Reason = waitErr.Error()
+		}
+		for _, handler := range handlers {
+			handler(stopped)
+		}
+		s.bus.Publish(stopped)
+		if current {
+			s.bus.Publish(events.StatusChanged{Running: false})
 		}
 	}
 }
@@ -271,19 +449,54 @@ func (s *XMRigService) monitorProcess() {
 // Stop 停止挖矿
 func (s *XMRigService) Stop() error {
 	s.mutex.Lock()
-	defer s.mutex.Unlock()
+	s.stopRequested = true
+	s.stopping++
+	cmd, done := s.cmd, s.done
+	running := s.isRunning && cmd != nil && cmd.Process != nil
+	s.mutex.Unlock()
+
+	// 终止进程最长要等待数秒，不持锁进行，期间状态与日志查询不受影响
+	var err error
+	if running {
+		err = s.backend.Terminate(cmd, done)
+	}
+	s.backend.Cleanup()
 
-	// 优先按PID停止当前跟踪的进程
-	if s.cmd != nil && s.cmd.Process != nil {
-		pid := s.cmd.Process.Pid
-		s.runSilent("taskkill", "/F", "/T", "/PID", strconv.Itoa(pid))
-		_ = s.cmd.Process.Kill()
+	s.mutex.Lock()
+	s.stopping--
+	s.isRunning = false
+	s.mutex.Unlock()
+	return err
+}
+
+// ProbePools 按当前策略探测所有已启用矿池，不启动挖矿
+func (s *XMRigService) ProbePools() (*models.PoolSelection, error) {
+	cfg, err := s.configSvc.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	_, selection, err := s.selector.Select(cfg.Pools, cfg.Manager.PoolStrategy, "")
+	if err != nil && len(selection.Probes) == 0 {
+		return nil, err
 	}
+	return selection, nil
+}
 
-	s.runSilent("taskkill", "/F", "/IM", "xmrig.exe")
+// LastPoolSelection 返回最近一次矿池选择的策略、结果与各矿池延迟
+func (s *XMRigService) LastPoolSelection() *models.PoolSelection {
+	return s.selector.Last()
+}
 
-	s.isRunning = false
-	return nil
+// PoolConnected 返回XMRig当前是否连接着矿池，优先使用HTTP API，不主动探测矿池
+func (s *XMRigService) PoolConnected() bool {
+	if cfg, err := s.configSvc.LoadConfig(); err == nil && cfg.HTTP.Enabled {
+		if summary, err := s.apiClient(cfg).Summary(context.Background()); err == nil {
+			return summary.Connection.Uptime > 0
+		}
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.poolConnected
 }
 
 // IsRunning 检查是否运行中
@@ -298,6 +511,8 @@ func (s *XMRigService) GetStatus() (*models.MinerStatus, error) {
 	s.mutex.RLock()
 	running := s.isRunning
 	startTime := s.startTime
+	invalidShares := s.invalidShares
+	currentPool := s.currentPool
 	s.mutex.RUnlock()
 
 	status := &models.MinerStatus{
@@ -307,28 +522,35 @@ func (s *XMRigService) GetStatus() (*models.MinerStatus, error) {
 
 	if running {
 		status.Uptime = int64(time.Since(startTime).Seconds())
+		status.Pool = currentPool
 
 		// 尝试从HTTP API获取详细状态
 		config, err := s.configSvc.LoadConfig()
 		if err == nil && config.HTTP.Enabled {
-			apiStatus, err := s.getAPIStatus(config.HTTP.Host, config.HTTP.Port)
+			summary, err := s.apiClient(config).Summary(context.Background())
 			if err == nil {
-				status.Hashrate = apiStatus.Hashrate
-				status.Threads = apiStatus.Threads
-				if len(config.Pools) > 0 {
-					status.Pool = config.Pools[0].URL
-				}
+				applySummary(status, summary)
+				status.SharesInvalid = invalidShares
 			}
 		}
 
-		if err == nil && len(config.Pools) > 0 {
+		if currentPool != "" {
 			s.mutex.RLock()
 			connected := s.poolConnected
 			s.mutex.RUnlock()
-			if connected {
+			if connected || status.Connected {
 				status.Connected = true
 			} else {
-				status.Connected = s.isPoolReachable(config.Pools[0].URL)
+				pool := models.PoolConfig{URL: currentPool}
+				if config != nil {
+					for _, p := range config.Pools {
+						if p.URL == currentPool {
+							pool = p
+							break
+						}
+					}
+				}
+				status.Connected = s.isPoolReachable(pool)
 			}
 		}
 	}
@@ -336,87 +558,79 @@ func (s *XMRigService) GetStatus() (*models.MinerStatus, error) {
 	return status, nil
 }
 
-func (s *XMRigService) isPoolReachable(poolURL string) bool {
-	u := strings.TrimSpace(poolURL)
-	if u == "" {
+// isPoolReachable 轻量检查矿池能否建立连接，不发送登录请求
+func (s *XMRigService) isPoolReachable(pool models.PoolConfig) bool {
+	u, err := stratum.ParseURL(pool.URL)
+	if err != nil {
 		return false
 	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	return stratum.Reachable(ctx, u.Address(), u.TLS || pool.TLS) == nil
+}
 
-	tlsMode := false
-	if idx := strings.Index(u, "://"); idx != -1 {
-		scheme := strings.ToLower(u[:idx])
-		tlsMode = strings.Contains(scheme, "ssl")
-		u = u[idx+3:]
+// probePool 以矿池配置中的钱包执行 stratum 登录探测
+func (s *XMRigService) probePool(ctx context.Context, pool models.PoolConfig) stratum.ProbeResult {
+	u, err := stratum.ParseURL(pool.URL)
+	if err != nil {
+		return stratum.ProbeResult{Class: stratum.FailureProtocol, Error: err.Error()}
 	}
-
-	addr := u
-	if !strings.Contains(addr, ":") {
-		addr = addr + ":443"
-		if !tlsMode {
-			addr = u + ":80"
-		}
+	opts := stratum.ProbeOptions{
+		Address: u.Address(),
+		TLS:     u.TLS || pool.TLS,
+		User:    pool.User,
+		Pass:    pool.Pass,
+		Timeout: stratum.DefaultProbeTimeout,
 	}
-
-	d := &net.Dialer{Timeout: 2 * time.Second}
-	if tlsMode {
-		conn, err := tls.DialWithDialer(d, "tcp", addr, &tls.Config{InsecureSkipVerify: true})
-		if err != nil {
-			return false
-		}
-		_ = conn.Close()
-		return true
+	if pool.Algo != nil && *pool.Algo != "" {
+		opts.Algo = []string{*pool.Algo}
 	}
-	conn, err := d.Dial("tcp", addr)
-	if err != nil {
-		return false
-	}
-	_ = conn.Close()
-	return true
+	return stratum.Probe(ctx, opts)
 }
 
-// APIResponse XMRig API响应结构
-type APIResponse struct {
-	Hashrate struct {
-		Total []float64 `json:"total"`
-	} `json:"hashrate"`
-	Resources struct {
-		Threads int `json:"threads"`
-	} `json:"resources"`
+// apiClient 根据配置创建XMRig HTTP API客户端
+func (s *XMRigService) apiClient(cfg *models.XMRigConfig) *xmrigapi.Client {
+	return xmrigapi.NewFromConfig(cfg.HTTP)
 }
 
-// getAPIStatus 从API获取状态
-func (s *XMRigService) getAPIStatus(host string, port int) (*models.MinerStatus, error) {
-	url := fmt.Sprintf("http://%s:%d/1/summary", host, port)
+// applySummary 将 /1/summary 的数据填入挖矿状态
+func applySummary(status *models.MinerStatus, summary *xmrigapi.Summary) {
+	status.Hashrate = summary.Hashrate.Current()
+	status.Hashrate10s = summary.Hashrate.Window(xmrigapi.Window10s)
+	status.Hashrate60s = summary.Hashrate.Window(xmrigapi.Window60s)
+	status.Hashrate15m = summary.Hashrate.Window(xmrigapi.Window15m)
+	status.HashrateHighest = summary.Hashrate.HighestValue()
 
-	client := &http.Client{Timeout: 3 * time.Second}
-	resp, err := client.Get(url)
-	if err != nil {
-		return nil, err
+	status.Threads = len(summary.Hashrate.Threads)
+	if status.Threads == 0 {
+		status.Threads = summary.Resources.Threads
 	}
-	defer resp.Body.Close()
 
-	var apiResp APIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
-		return nil, err
+	status.Algorithm = summary.Algo
+	if summary.Connection.Algo != "" {
+		status.Algorithm = summary.Connection.Algo
 	}
-
-	hashrate := 0.0
-	if len(apiResp.Hashrate.Total) > 0 {
-		hashrate = apiResp.Hashrate.Total[0]
+	status.PoolPing = summary.Connection.Ping
+	status.Difficulty = summary.Results.DiffCurrent
+	if status.Difficulty == 0 {
+		status.Difficulty = summary.Connection.Diff
 	}
 
-	return &models.MinerStatus{
-		Hashrate: hashrate,
-		Threads:  apiResp.Resources.Threads,
-	}, nil
+	status.SharesAccepted = summary.Results.SharesGood
+	if summary.Results.SharesTotal > summary.Results.SharesGood {
+		status.SharesRejected = summary.Results.SharesTotal - summary.Results.SharesGood
+	}
+	if summary.Connection.Uptime > 0 {
+		status.Connected = true
+	}
 }
 
 // GetLogs 获取日志
-func (s *XMRigService) GetLogs() []string {
+func (s *XMRigService) GetLogs() []models.LogRecord {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
-	logs := make([]string, len(s.logBuffer))
+	logs := make([]models.LogRecord, len(s.logBuffer))
 	copy(logs, s.logBuffer)
 	return logs
 }
@@ -425,44 +639,59 @@ func (s *XMRigService) GetLogs() []string {
 func (s *XMRigService) ClearLogs() {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
-	s.logBuffer = make([]string, 0, s.maxLogLines)
+	s.logBuffer = make([]models.LogRecord, 0, s.maxLogLines)
 }
 
 // GetSystemInfo 获取系统信息
 func (s *XMRigService) GetSystemInfo() (*models.SystemInfo, error) {
-	xmrigVersion := s.getXMRigVersion()
-
-	return &models.SystemInfo{
-		OS:           runtime.GOOS,
-		Arch:         runtime.GOARCH,
-		CPUCores:     runtime.NumCPU(),
-		CPUModel:     "Unknown",
-		TotalMemory:  0,
+	provider, exePath, err := s.getXMRigExecutable()
+	xmrigVersion := "Unknown"
+	if err == nil {
+		if output, err := s.versionOutput(exePath); err == nil {
+			xmrigVersion = parseXMRigVersion(output)
+		}
+	}
+	source := ""
+	if provider != nil {
+		source = provider.Name()
+	}
+
+	info := &models.SystemInfo{
 		XMRigVersion: xmrigVersion,
-	}, nil
+		XMRigPath:    exePath,
+		XMRigSource:  source,
+	}
+	if err := sysinfo.Collect(info); err != nil {
+		info.Error = err.Error()
+	}
+	return info, nil
 }
 
-// getXMRigVersion 获取XMRig版本号
-func (s *XMRigService) getXMRigVersion() string {
-	exePath, err := s.getXMRigExecutable()
+// XMRigVersionOutput 返回 xmrig --version 的完整输出，包含编译器与依赖库版本
+func (s *XMRigService) XMRigVersionOutput() (string, error) {
+	_, exePath, err := s.getXMRigExecutable()
 	if err != nil {
-		return "Unknown"
+		return "", err
 	}
+	return s.versionOutput(exePath)
+}
 
+// versionOutput 运行 xmrig --version，exePath 必须是 getXMRigExecutable 校验过的路径
+func (s *XMRigService) versionOutput(exePath string) (string, error) {
 	cmd := exec.Command(exePath, "--version")
-	// Windows下隐藏cmd窗口
-	cmd.SysProcAttr = &syscall.SysProcAttr{
-		HideWindow:    true,
-		CreationFlags: 0x08000000, // CREATE_NO_WINDOW
-	}
-	output, err := cmd.Output()
+	s.backend.Prepare(cmd)
+	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return "Unknown"
+		return string(output), fmt.Errorf("运行 %s --version 失败: %w", exePath, err)
 	}
+	return string(output), nil
+}
 
+// parseXMRigVersion 从 --version 的输出中解析XMRig版本号
+func parseXMRigVersion(output string) string {
 	// 解析版本信息，通常第一行包含版本号
 	// 例如: XMRig 6.24.0
-	lines := strings.Split(string(output), "\n")
+	lines := strings.Split(output, "\n")
 	if len(lines) > 0 {
 		line := strings.TrimSpace(lines[0])
 		// 提取版本号
@@ -0,0 +1,279 @@
+// Package stratum 实现矿池探测：建立 TCP/TLS 连接后以配置的钱包执行 stratum login，
+// 等待矿池下发任务，并对失败原因分类。
+package stratum
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+)
+
+// 探测失败分类
+const (
+	FailureNone     = ""
+	FailureDNS      = "dns"      // 域名解析失败
+	FailureTCP      = "tcp"      // TCP 连接失败
+	FailureTLS      = "tls"      // TLS 握手失败
+	FailureAuth     = "auth"     // 登录被拒绝
+	FailureTimeout  = "timeout"  // 超时未收到登录响应
+	FailureClosed   = "closed"   // 收到登录响应前矿池关闭了连接
+	FailureNoJob    = "no-job"   // 登录成功但未收到任务
+	FailureProtocol = "protocol" // 响应不符合 stratum 协议
+)
+
+// DefaultProbeTimeout 默认探测超时
+const DefaultProbeTimeout = 5 * time.Second
+
+// defaultAgent 登录时上报的客户端标识
+const defaultAgent = "xdag-miner-probe/1.0"
+
+// ProbeOptions 探测参数
+type ProbeOptions struct {
+	Address string        // host:port
+	TLS     bool          // 是否使用 TLS
+	User    string        // 钱包地址或矿池用户名
+	Pass    string        // 密码
+	Algo    []string      // 支持的算法，为空时不上报
+	Agent   string        // 客户端标识，为空时使用默认值
+	Timeout time.Duration // 整体超时，ctx 无截止时间时生效
+}
+
+// ProbeResult 探测结果
+type ProbeResult struct {
+	OK          bool          `json:"ok"`
+	Class       string        `json:"class"`
+	Error       string        `json:"error,omitempty"`
+	ConnectTime time.Duration `json:"connectTime"` // 建立连接（含 TLS 握手）耗时
+	Latency     time.Duration `json:"latency"`     // 发送 login 到收到响应的耗时
+}
+
+// Err 以 error 形式返回失败原因，成功时为 nil
+func (r ProbeResult) Err() error {
+	if r.OK {
+		return nil
+	}
+	return fmt.Errorf("%s: %s", ClassMessage(r.Class), r.Error)
+}
+
+// ClassMessage 返回失败分类的说明
+func ClassMessage(class string) string {
+	switch class {
+	case FailureNone:
+		return "正常"
+	case FailureDNS:
+		return "域名解析失败"
+	case FailureTCP:
+		return "无法连接"
+	case FailureTLS:
+		return "TLS 握手失败"
+	case FailureAuth:
+		return "登录被拒绝"
+	case FailureTimeout:
+		return "登录超时"
+	case FailureClosed:
+		return "连接被关闭"
+	case FailureNoJob:
+		return "未收到任务"
+	case FailureProtocol:
+		return "协议错误"
+	default:
+		return class
+	}
+}
+
+// rpcRequest stratum 请求
+type rpcRequest struct {
+	ID      int         `json:"id"`
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params"`
+}
+
+// loginParams login 请求参数
+type loginParams struct {
+	Login string   `json:"login"`
+	Pass  string   `json:"pass"`
+	Agent string   `json:"agent"`
+	Algo  []string `json:"algo,omitempty"`
+}
+
+// rpcMessage stratum 响应或通知
+type rpcMessage struct {
+	ID     *int            `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result *struct {
+		ID     string          `json:"id"`
+		Job    json.RawMessage `json:"job"`
+		Status string          `json:"status"`
+	} `json:"result"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Probe 连接矿池并执行 login，直到收到任务或失败
+func Probe(ctx context.Context, opts ProbeOptions) ProbeResult {
+	if _, ok := ctx.Deadline(); !ok {
+		timeout := opts.Timeout
+		if timeout <= 0 {
+			timeout = DefaultProbeTimeout
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	conn, class, err := dial(ctx, opts.Address, opts.TLS)
+	if err != nil {
+		return ProbeResult{Class: class, Error: err.Error()}
+	}
+	defer conn.Close()
+	result := ProbeResult{ConnectTime: time.Since(start)}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	// ctx 取消时中断阻塞的读写
+	stop := context.AfterFunc(ctx, func() { _ = conn.SetDeadline(time.Now()) })
+	defer stop()
+
+	agent := opts.Agent
+	if agent == "" {
+		agent = defaultAgent
+	}
+	req := rpcRequest{
+		ID:      1,
+		JSONRPC: "2.0",
+		Method:  "login",
+		Params:  loginParams{Login: opts.User, Pass: opts.Pass, Agent: agent, Algo: opts.Algo},
+	}
+	data, err := json.Marshal(req)
+	if err != nil {
+		return fail(result, FailureProtocol, err)
+	}
+	sent := time.Now()
+	if _, err := conn.Write(append(data, '\n')); err != nil {
+		return fail(result, FailureTCP, err)
+	}
+
+	reader := bufio.NewReaderSize(conn, 64*1024)
+	loggedIn := false
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			switch {
+			case loggedIn && isTimeout(err):
+				return fail(result, FailureNoJob, fmt.Errorf("登录成功但超时未收到任务"))
+			case loggedIn:
+				return fail(result, FailureNoJob, err)
+			case errors.Is(err, io.EOF):
+				// 没有错误响应就断开，可能是钱包地址无效，也可能是端口或协议不对，不能断定为登录被拒绝
+				return fail(result, FailureClosed, fmt.Errorf("矿池在登录响应前关闭了连接"))
+			case isTimeout(err):
+				return fail(result, FailureTimeout, fmt.Errorf("等待登录响应超时"))
+			default:
+				return fail(result, FailureTCP, err)
+			}
+		}
+
+		var msg rpcMessage
+		if err := json.Unmarshal(line, &msg); err != nil {
+			return fail(result, FailureProtocol, fmt.Errorf("无法解析响应: %w", err))
+		}
+
+		switch {
+		case msg.ID != nil && *msg.ID == req.ID:
+			result.Latency = time.Since(sent)
+			if msg.Error != nil {
+				return fail(result, FailureAuth, fmt.Errorf("%s (%d)", msg.Error.Message, msg.Error.Code))
+			}
+			if msg.Result == nil {
+				return fail(result, FailureProtocol, fmt.Errorf("login 响应缺少 result"))
+			}
+			if hasJob(msg.Result.Job) {
+				result.OK = true
+				return result
+			}
+			loggedIn = true
+		case msg.Method == "job":
+			if hasJob(msg.Params) {
+				if result.Latency == 0 {
+					result.Latency = time.Since(sent)
+				}
+				result.OK = true
+				return result
+			}
+		}
+	}
+}
+
+// Reachable 仅检查能否建立 TCP/TLS 连接，不发送登录请求
+func Reachable(ctx context.Context, address string, useTLS bool) error {
+	conn, _, err := dial(ctx, address, useTLS)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// dial 建立连接并返回失败分类
+func dial(ctx context.Context, address string, useTLS bool) (net.Conn, string, error) {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, FailureDNS, err
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", address)
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) {
+			return nil, FailureDNS, err
+		}
+		return nil, FailureTCP, err
+	}
+	if !useTLS {
+		return conn, FailureNone, nil
+	}
+
+	// 矿池普遍使用自签名证书，XMRig 默认同样不校验证书链
+	tlsConn := tls.Client(conn, &tls.Config{ServerName: host, InsecureSkipVerify: true})
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		conn.Close()
+		return nil, FailureTLS, err
+	}
+	return tlsConn, FailureNone, nil
+}
+
+// hasJob 判断任务字段是否为非空对象
+func hasJob(raw json.RawMessage) bool {
+	if len(raw) == 0 || string(raw) == "null" {
+		return false
+	}
+	var job map[string]interface{}
+	if err := json.Unmarshal(raw, &job); err != nil {
+		return false
+	}
+	return len(job) > 0
+}
+
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+func fail(result ProbeResult, class string, err error) ProbeResult {
+	result.OK = false
+	result.Class = class
+	result.Error = err.Error()
+	return result
+}
@@ -0,0 +1,138 @@
+package stratum
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+// fakePool 启动只接受一个连接的假矿池，收到 login 后调用 reply 应答，返回监听地址
+func fakePool(t *testing.T, reply func(conn net.Conn)) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		line, err := bufio.NewReader(conn).ReadBytes('\n')
+		if err != nil {
+			return
+		}
+		var req struct {
+			Method string      `json:"method"`
+			Params loginParams `json:"params"`
+		}
+		if err := json.Unmarshal(line, &req); err != nil || req.Method != "login" || req.Params.Login != "wallet" {
+			t.Errorf("矿池收到 %s", line)
+		}
+		reply(conn)
+		// 保持连接直到探测方关闭
+		io.Copy(io.Discard, conn)
+	}()
+	return ln.Addr().String()
+}
+
+// send 返回依次写入各行的应答
+func send(lines ...string) func(conn net.Conn) {
+	return func(conn net.Conn) {
+		for _, line := range lines {
+			io.WriteString(conn, line+"\n")
+		}
+	}
+}
+
+func TestProbe(t *testing.T) {
+	const job = `{"job_id":"1","blob":"0707","target":"b88d0600"}`
+	tests := []struct {
+		name   string
+		reply  func(conn net.Conn)
+		wantOK bool
+		want   string
+	}{
+		{
+			name:   "登录响应携带任务",
+			reply:  send(`{"id":1,"jsonrpc":"2.0","error":null,"result":{"id":"w1","job":` + job + `,"status":"OK"}}`),
+			wantOK: true,
+			want:   FailureNone,
+		},
+		{
+			name: "登录后通知下发任务",
+			reply: send(
+				`{"id":1,"jsonrpc":"2.0","result":{"id":"w1","status":"OK"}}`,
+				`{"jsonrpc":"2.0","method":"job","params":`+job+`}`,
+			),
+			wantOK: true,
+			want:   FailureNone,
+		},
+		{
+			name:  "钱包地址被拒绝",
+			reply: send(`{"id":1,"jsonrpc":"2.0","error":{"code":-1,"message":"Invalid address used for login"}}`),
+			want:  FailureAuth,
+		},
+		{
+			name:  "登录响应前断开",
+			reply: func(conn net.Conn) { conn.Close() },
+			want:  FailureClosed,
+		},
+		{
+			name:  "超时未收到任务",
+			reply: send(`{"id":1,"jsonrpc":"2.0","result":{"id":"w1","status":"OK"}}`),
+			want:  FailureNoJob,
+		},
+		{
+			name:  "超时未收到登录响应",
+			reply: send(),
+			want:  FailureTimeout,
+		},
+		{
+			name:  "响应不是 JSON",
+			reply: send(`HTTP/1.1 400 Bad Request`),
+			want:  FailureProtocol,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := fakePool(t, tt.reply)
+			result := Probe(context.Background(), ProbeOptions{
+				Address: addr,
+				User:    "wallet",
+				Pass:    "x",
+				Timeout: 300 * time.Millisecond,
+			})
+			if result.OK != tt.wantOK || result.Class != tt.want {
+				t.Fatalf("得到 OK=%v 分类=%q (%s)，期望 OK=%v 分类=%q", result.OK, result.Class, result.Error, tt.wantOK, tt.want)
+			}
+			if tt.wantOK && result.Latency <= 0 {
+				t.Errorf("成功时未记录延迟")
+			}
+		})
+	}
+}
+
+func TestProbeConnectionRefused(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	result := Probe(context.Background(), ProbeOptions{Address: addr, User: "wallet", Timeout: time.Second})
+	if result.OK || result.Class != FailureTCP {
+		t.Fatalf("得到 OK=%v 分类=%q (%s)，期望分类 %q", result.OK, result.Class, result.Error, FailureTCP)
+	}
+	if result.Err() == nil {
+		t.Error("失败结果的 Err 为 nil")
+	}
+}
@@ -0,0 +1,149 @@
+package stratum
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// 支持的矿池地址协议
+const (
+	SchemeTCP = "stratum+tcp"
+	SchemeSSL = "stratum+ssl"
+	SchemeTLS = "stratum+tls"
+)
+
+// 未指定端口时各协议的默认端口
+const (
+	DefaultTCPPort = 3333
+	DefaultTLSPort = 443
+)
+
+// URL 解析后的矿池地址
+type URL struct {
+	Scheme string // 未写协议时为空，按 stratum+tcp 处理
+	Host   string // 域名或 IP，IPv6 不含方括号
+	Port   int
+	TLS    bool // 协议是否要求 TLS
+}
+
+// ParseURL 解析矿池地址，支持 stratum+tcp/ssl/tls 协议、省略协议的 host:port
+// 与 [IPv6]:port 形式，未指定端口时使用协议默认端口
+func ParseURL(raw string) (*URL, error) {
+	s := strings.TrimSpace(raw)
+	if s == "" {
+		return nil, fmt.Errorf("矿池地址不能为空")
+	}
+	if strings.ContainsAny(s, " \t") {
+		return nil, fmt.Errorf("矿池地址不能包含空格")
+	}
+
+	u := &URL{}
+	if idx := strings.Index(s, "://"); idx != -1 {
+		u.Scheme = strings.ToLower(s[:idx])
+		s = s[idx+3:]
+		switch u.Scheme {
+		case SchemeTCP:
+		case SchemeSSL, SchemeTLS:
+			u.TLS = true
+		default:
+			return nil, fmt.Errorf("不支持的协议 %q，请使用 %s、%s 或 %s", u.Scheme, SchemeTCP, SchemeSSL, SchemeTLS)
+		}
+	}
+	s = strings.TrimSuffix(s, "/")
+	if strings.ContainsAny(s, "/?#@") {
+		return nil, fmt.Errorf("矿池地址只能包含主机和端口")
+	}
+	if s == "" {
+		return nil, fmt.Errorf("矿池地址缺少主机")
+	}
+
+	host, portStr, err := splitHostPort(s)
+	if err != nil {
+		return nil, err
+	}
+	if host == "" {
+		return nil, fmt.Errorf("矿池地址缺少主机")
+	}
+	u.Host = host
+
+	if portStr == "" {
+		u.Port = DefaultTCPPort
+		if u.TLS {
+			u.Port = DefaultTLSPort
+		}
+	} else {
+		port, err := strconv.Atoi(portStr)
+		if err != nil || port < 1 || port > 65535 {
+			return nil, fmt.Errorf("端口 %q 无效，应为 1-65535", portStr)
+		}
+		u.Port = port
+	}
+	return u, nil
+}
+
+// splitHostPort 拆分主机与端口，端口可省略
+func splitHostPort(s string) (string, string, error) {
+	if strings.HasPrefix(s, "[") {
+		end := strings.Index(s, "]")
+		if end == -1 {
+			return "", "", fmt.Errorf("IPv6 地址缺少 ]")
+		}
+		host := s[1:end]
+		if net.ParseIP(host) == nil || !strings.Contains(host, ":") {
+			return "", "", fmt.Errorf("IPv6 地址 %q 无效", host)
+		}
+		rest := s[end+1:]
+		if rest == "" {
+			return host, "", nil
+		}
+		if !strings.HasPrefix(rest, ":") || rest == ":" {
+			return "", "", fmt.Errorf("IPv6 地址后应为 :端口")
+		}
+		return host, rest[1:], nil
+	}
+
+	switch strings.Count(s, ":") {
+	case 0:
+		return s, "", nil
+	case 1:
+		host, port, _ := strings.Cut(s, ":")
+		if port == "" {
+			// 写了冒号却没有端口多半是输入错误，不按默认端口处理
+			return "", "", fmt.Errorf("矿池地址缺少端口，省略端口时请同时去掉冒号")
+		}
+		return host, port, nil
+	default:
+		return "", "", fmt.Errorf("IPv6 地址需要用方括号包裹，如 [::1]:3333")
+	}
+}
+
+// Address 返回可直接拨号的 host:port
+func (u *URL) Address() string {
+	return net.JoinHostPort(u.Host, strconv.Itoa(u.Port))
+}
+
+// String 返回规范化的地址
+func (u *URL) String() string {
+	scheme := u.Scheme
+	if scheme == "" {
+		scheme = SchemeTCP
+	}
+	return scheme + "://" + u.Address()
+}
+
+// CheckTLS 检查地址协议与矿池配置中的 tls 开关是否一致；
+// 未写协议时以 tls 开关为准
+func (u *URL) CheckTLS(tls bool) error {
+	if u.Scheme == "" {
+		return nil
+	}
+	if u.TLS && !tls {
+		return fmt.Errorf("地址使用 %s 协议，请同时开启 TLS", u.Scheme)
+	}
+	if !u.TLS && tls {
+		return fmt.Errorf("地址使用 %s 协议，但开启了 TLS，请改用 %s", u.Scheme, SchemeSSL)
+	}
+	return nil
+}
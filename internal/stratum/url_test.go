@@ -0,0 +1,89 @@
+package stratum
+
+import "testing"
+
+func TestParseURL(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    URL
+		address string
+		wantErr bool
+	}{
+		{raw: "stratum+tcp://stratum.xdag.org:23656", want: URL{Scheme: SchemeTCP, Host: "stratum.xdag.org", Port: 23656}, address: "stratum.xdag.org:23656"},
+		{raw: "  STRATUM+TCP://pool.example.com:3333/  ", want: URL{Scheme: SchemeTCP, Host: "pool.example.com", Port: 3333}, address: "pool.example.com:3333"},
+		{raw: "stratum+ssl://pool.example.com:5555", want: URL{Scheme: SchemeSSL, Host: "pool.example.com", Port: 5555, TLS: true}, address: "pool.example.com:5555"},
+		{raw: "stratum+tls://pool.example.com:5555", want: URL{Scheme: SchemeTLS, Host: "pool.example.com", Port: 5555, TLS: true}, address: "pool.example.com:5555"},
+		{raw: "pool.example.com:3333", want: URL{Host: "pool.example.com", Port: 3333}, address: "pool.example.com:3333"},
+		{raw: "127.0.0.1:3333", want: URL{Host: "127.0.0.1", Port: 3333}, address: "127.0.0.1:3333"},
+		{raw: "[::1]:3333", want: URL{Host: "::1", Port: 3333}, address: "[::1]:3333"},
+		{raw: "stratum+ssl://[2001:db8::1]:443", want: URL{Scheme: SchemeSSL, Host: "2001:db8::1", Port: 443, TLS: true}, address: "[2001:db8::1]:443"},
+
+		// 省略端口时使用协议默认端口
+		{raw: "pool.example.com", want: URL{Host: "pool.example.com", Port: DefaultTCPPort}, address: "pool.example.com:3333"},
+		{raw: "stratum+tcp://pool.example.com", want: URL{Scheme: SchemeTCP, Host: "pool.example.com", Port: DefaultTCPPort}, address: "pool.example.com:3333"},
+		{raw: "stratum+ssl://pool.example.com", want: URL{Scheme: SchemeSSL, Host: "pool.example.com", Port: DefaultTLSPort, TLS: true}, address: "pool.example.com:443"},
+		{raw: "[::1]", want: URL{Host: "::1", Port: DefaultTCPPort}, address: "[::1]:3333"},
+
+		{raw: "", wantErr: true},
+		{raw: "pool.example.com:0", wantErr: true},
+		{raw: "pool.example.com:65536", wantErr: true},
+		{raw: "pool.example.com:-1", wantErr: true},
+		{raw: "pool.example.com:abc", wantErr: true},
+		{raw: "pool.example.com:", wantErr: true},
+		{raw: ":3333", wantErr: true},
+		{raw: "http://pool.example.com:3333", wantErr: true},
+		{raw: "stratum+tcp://", wantErr: true},
+		{raw: "stratum+tcp://user@pool.example.com:3333", wantErr: true},
+		{raw: "stratum+tcp://pool.example.com:3333/path", wantErr: true},
+		{raw: "pool example.com:3333", wantErr: true},
+		{raw: "::1:3333", wantErr: true},
+		{raw: "[::1:3333", wantErr: true},
+		{raw: "[example.com]:3333", wantErr: true},
+		{raw: "[::1]3333", wantErr: true},
+		{raw: "[::1]:", wantErr: true},
+	}
+	for _, tt := range tests {
+		u, err := ParseURL(tt.raw)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%q: 得到 %+v，期望错误", tt.raw, u)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: %v", tt.raw, err)
+			continue
+		}
+		if *u != tt.want {
+			t.Errorf("%q: 得到 %+v，期望 %+v", tt.raw, *u, tt.want)
+		}
+		if u.Address() != tt.address {
+			t.Errorf("%q: 地址得到 %s，期望 %s", tt.raw, u.Address(), tt.address)
+		}
+	}
+}
+
+func TestURLCheckTLS(t *testing.T) {
+	tests := []struct {
+		raw     string
+		tls     bool
+		wantErr bool
+	}{
+		{raw: "stratum+tcp://pool.example.com:3333"},
+		{raw: "stratum+tcp://pool.example.com:3333", tls: true, wantErr: true},
+		{raw: "stratum+ssl://pool.example.com:443", tls: true},
+		{raw: "stratum+ssl://pool.example.com:443", wantErr: true},
+		// 未写协议时以 tls 开关为准
+		{raw: "pool.example.com:443", tls: true},
+		{raw: "pool.example.com:3333"},
+	}
+	for _, tt := range tests {
+		u, err := ParseURL(tt.raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := u.CheckTLS(tt.tls); (err != nil) != tt.wantErr {
+			t.Errorf("%q tls=%v: 得到 %v，期望错误=%v", tt.raw, tt.tls, err, tt.wantErr)
+		}
+	}
+}
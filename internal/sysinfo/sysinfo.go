@@ -0,0 +1,77 @@
+// Package sysinfo 读取 CPU 型号、核心与缓存、NUMA 节点、指令集、内存与大页状态，
+// 供界面展示与挖矿参数调整参考。
+package sysinfo
+
+import (
+	"errors"
+	"go-wails/internal/models"
+	"runtime"
+	"sort"
+
+	"golang.org/x/sys/cpu"
+)
+
+// ErrUnsupported 当前系统不支持读取硬件信息
+var ErrUnsupported = errors.New("当前系统不支持读取硬件信息")
+
+// Collect 填充 info 中的硬件字段。无法读取的字段保留 runtime 提供的值或零值，
+// 返回的错误只表示部分信息缺失
+func Collect(info *models.SystemInfo) error {
+	info.OS = runtime.GOOS
+	info.Arch = runtime.GOARCH
+	info.CPUCores = runtime.NumCPU()
+	info.CPUModel = "Unknown"
+	info.NUMANodes = 1
+	info.AES, info.AVX2 = cpuFeatures()
+
+	err := collect(info)
+	sortCaches(info.CPUCaches)
+	return err
+}
+
+// cpuFeatures 通过 CPUID 等方式检测 AES 与 AVX2，AVX2 同时要求操作系统已启用
+func cpuFeatures() (aes, avx2 bool) {
+	switch runtime.GOARCH {
+	case "amd64", "386":
+		return cpu.X86.HasAES, cpu.X86.HasAVX2
+	case "arm64":
+		return cpu.ARM64.HasAES, false
+	}
+	return false, false
+}
+
+// cacheKey 缓存的级别与类型
+type cacheKey struct {
+	level     int
+	cacheType string
+}
+
+// cacheSet 按级别与类型累计缓存实例
+type cacheSet map[cacheKey]*models.CPUCache
+
+func (c cacheSet) add(level int, cacheType string, size uint64) {
+	key := cacheKey{level, cacheType}
+	if entry, ok := c[key]; ok {
+		entry.Count++
+		return
+	}
+	c[key] = &models.CPUCache{Level: level, Type: cacheType, Size: size, Count: 1}
+}
+
+func (c cacheSet) list() []models.CPUCache {
+	caches := make([]models.CPUCache, 0, len(c))
+	for _, entry := range c {
+		caches = append(caches, *entry)
+	}
+	return caches
+}
+
+// sortCaches 按级别排序，同级别数据缓存在指令缓存之前
+func sortCaches(caches []models.CPUCache) {
+	sort.Slice(caches, func(i, j int) bool {
+		if caches[i].Level != caches[j].Level {
+			return caches[i].Level < caches[j].Level
+		}
+		return caches[i].Type < caches[j].Type
+	})
+}
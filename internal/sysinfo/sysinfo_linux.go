@@ -0,0 +1,268 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"go-wails/internal/models"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	cpuInfoPath  = "/proc/cpuinfo"
+	memInfoPath  = "/proc/meminfo"
+	cpuSysPath   = "/sys/devices/system/cpu"
+	nodeSysPath  = "/sys/devices/system/node"
+	hugePagePath = "/sys/kernel/mm/hugepages"
+)
+
+var (
+	cpuDirPattern  = regexp.MustCompile(`^cpu[0-9]+$`)
+	nodeDirPattern = regexp.MustCompile(`^node[0-9]+$`)
+)
+
+func collect(info *models.SystemInfo) error {
+	var errs []error
+	if err := readCPUInfo(info); err != nil {
+		errs = append(errs, err)
+	}
+	if err := readMemInfo(info); err != nil {
+		errs = append(errs, err)
+	}
+	info.CPUCaches = readCaches()
+	if nodes := countDirs(nodeSysPath, nodeDirPattern); nodes > 0 {
+		info.NUMANodes = nodes
+	}
+	if _, err := os.Stat(filepath.Join(hugePagePath, "hugepages-1048576kB")); err == nil {
+		info.HugePages.OneGB = true
+	}
+	return errors.Join(errs...)
+}
+
+// readCPUInfo 从 /proc/cpuinfo 读取型号、逻辑与物理核心数以及指令集标志
+func readCPUInfo(info *models.SystemInfo) error {
+	file, err := os.Open(cpuInfoPath)
+	if err != nil {
+		return fmt.Errorf("读取 CPU 信息失败: %w", err)
+	}
+	defer file.Close()
+
+	logical := 0
+	cores := make(map[string]bool)
+	physicalID, coreID := "", ""
+	flagsSeen := false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			// 空行分隔各逻辑处理器
+			if coreID != "" {
+				cores[physicalID+"/"+coreID] = true
+			}
+			physicalID, coreID = "", ""
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		switch key {
+		case "processor":
+			logical++
+		case "model name", "Hardware":
+			if info.CPUModel == "Unknown" && value != "" {
+				info.CPUModel = value
+			}
+		case "physical id":
+			physicalID = value
+		case "core id":
+			coreID = value
+		case "flags", "Features":
+			if !flagsSeen {
+				flagsSeen = true
+				flags := strings.Fields(value)
+				info.AES = hasFlag(flags, "aes")
+				info.AVX2 = hasFlag(flags, "avx2")
+			}
+		}
+	}
+	if coreID != "" {
+		cores[physicalID+"/"+coreID] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("读取 CPU 信息失败: %w", err)
+	}
+
+	if logical > 0 {
+		info.CPUCores = logical
+	}
+	if len(cores) > 0 {
+		info.CPUPhysicalCores = len(cores)
+	} else {
+		// ARM 等平台的 cpuinfo 没有 core id，改从拓扑目录统计
+		info.CPUPhysicalCores = countTopologyCores()
+	}
+	return nil
+}
+
+func hasFlag(flags []string, name string) bool {
+	for _, f := range flags {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+// countTopologyCores 按封装与核心编号统计物理核心数
+func countTopologyCores() int {
+	cores := make(map[string]bool)
+	for _, dir := range listDirs(cpuSysPath, cpuDirPattern) {
+		topology := filepath.Join(dir, "topology")
+		coreID, err := readString(filepath.Join(topology, "core_id"))
+		if err != nil {
+			continue
+		}
+		packageID, _ := readString(filepath.Join(topology, "physical_package_id"))
+		cores[packageID+"/"+coreID] = true
+	}
+	return len(cores)
+}
+
+// readCaches 从 /sys 读取各级缓存，共享同一缓存的逻辑处理器只计一次
+func readCaches() []models.CPUCache {
+	caches := make(cacheSet)
+	seen := make(map[string]bool)
+	for _, dir := range listDirs(cpuSysPath, cpuDirPattern) {
+		indexes, _ := filepath.Glob(filepath.Join(dir, "cache", "index[0-9]*"))
+		for _, index := range indexes {
+			level, err := readInt(filepath.Join(index, "level"))
+			if err != nil {
+				continue
+			}
+			cacheType, _ := readString(filepath.Join(index, "type"))
+			cacheType = strings.ToLower(cacheType)
+			sizeText, _ := readString(filepath.Join(index, "size"))
+			size := parseSize(sizeText)
+			shared, _ := readString(filepath.Join(index, "shared_cpu_list"))
+			key := fmt.Sprintf("%d/%s/%s", level, cacheType, shared)
+			if size == 0 || seen[key] {
+				continue
+			}
+			seen[key] = true
+			caches.add(int(level), cacheType, size)
+		}
+	}
+	return caches.list()
+}
+
+// readMemInfo 从 /proc/meminfo 读取内存与大页
+func readMemInfo(info *models.SystemInfo) error {
+	file, err := os.Open(memInfoPath)
+	if err != nil {
+		return fmt.Errorf("读取内存信息失败: %w", err)
+	}
+	defer file.Close()
+
+	values := make(map[string]uint64)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(value)
+		if len(fields) == 0 {
+			continue
+		}
+		n, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		if len(fields) > 1 && fields[1] == "kB" {
+			n <<= 10
+		}
+		values[key] = n
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("读取内存信息失败: %w", err)
+	}
+
+	info.TotalMemory = values["MemTotal"]
+	if available, ok := values["MemAvailable"]; ok {
+		info.FreeMemory = available
+	} else {
+		info.FreeMemory = values["MemFree"]
+	}
+	if size, ok := values["Hugepagesize"]; ok {
+		info.HugePages.Supported = true
+		info.HugePages.PageSize = size
+		info.HugePages.Total = values["HugePages_Total"]
+		info.HugePages.Free = values["HugePages_Free"]
+		info.HugePages.Available = info.HugePages.Free > 0
+	}
+	return nil
+}
+
+// parseSize 解析 /sys 中形如 32K、8M 的大小
+func parseSize(s string) uint64 {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0
+	}
+	shift := 0
+	switch s[len(s)-1] {
+	case 'K':
+		shift = 10
+	case 'M':
+		shift = 20
+	case 'G':
+		shift = 30
+	}
+	if shift > 0 {
+		s = s[:len(s)-1]
+	}
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return n << shift
+}
+
+func listDirs(parent string, pattern *regexp.Regexp) []string {
+	entries, err := os.ReadDir(parent)
+	if err != nil {
+		return nil
+	}
+	var dirs []string
+	for _, e := range entries {
+		if e.IsDir() && pattern.MatchString(e.Name()) {
+			dirs = append(dirs, filepath.Join(parent, e.Name()))
+		}
+	}
+	return dirs
+}
+
+func countDirs(parent string, pattern *regexp.Regexp) int {
+	return len(listDirs(parent, pattern))
+}
+
+func readString(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func readInt(path string) (int64, error) {
+	s, err := readString(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
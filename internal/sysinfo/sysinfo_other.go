@@ -0,0 +1,9 @@
+//go:build !linux && !windows
+
+package sysinfo
+
+import "go-wails/internal/models"
+
+func collect(info *models.SystemInfo) error {
+	return ErrUnsupported
+}
@@ -0,0 +1,218 @@
+//go:build windows
+
+package sysinfo
+
+import (
+	"errors"
+	"fmt"
+	"go-wails/internal/models"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+// GetLogicalProcessorInformation 的关系类型
+const (
+	relationProcessorCore = 0
+	relationNumaNode      = 1
+	relationCache         = 2
+)
+
+const (
+	tokenPrivileges     = 3 // TOKEN_INFORMATION_CLASS.TokenPrivileges
+	lockMemoryPrivilege = "SeLockMemoryPrivilege"
+	processorKey        = `HARDWARE\DESCRIPTION\System\CentralProcessor\0`
+)
+
+var (
+	kernel32                           = syscall.NewLazyDLL("kernel32.dll")
+	advapi32                           = syscall.NewLazyDLL("advapi32.dll")
+	procGetLogicalProcessorInformation = kernel32.NewProc("GetLogicalProcessorInformation")
+	procGlobalMemoryStatusEx           = kernel32.NewProc("GlobalMemoryStatusEx")
+	procGetLargePageMinimum            = kernel32.NewProc("GetLargePageMinimum")
+	procLookupPrivilegeValue           = advapi32.NewProc("LookupPrivilegeValueW")
+)
+
+// logicalProcessorInfo 对应 SYSTEM_LOGICAL_PROCESSOR_INFORMATION，Data 为 16 字节的联合体
+type logicalProcessorInfo struct {
+	ProcessorMask uintptr
+	Relationship  uint32
+	Data          [2]uint64
+}
+
+// cacheDescriptor 对应 CACHE_DESCRIPTOR
+type cacheDescriptor struct {
+	Level         uint8
+	Associativity uint8
+	LineSize      uint16
+	Size          uint32
+	Type          uint32
+}
+
+// memoryStatusEx 对应 MEMORYSTATUSEX
+type memoryStatusEx struct {
+	Length               uint32
+	MemoryLoad           uint32
+	TotalPhys            uint64
+	AvailPhys            uint64
+	TotalPageFile        uint64
+	AvailPageFile        uint64
+	TotalVirtual         uint64
+	AvailVirtual         uint64
+	AvailExtendedVirtual uint64
+}
+
+// luidAndAttributes 对应 LUID_AND_ATTRIBUTES
+type luidAndAttributes struct {
+	LowPart    uint32
+	HighPart   int32
+	Attributes uint32
+}
+
+var cacheTypes = []string{"unified", "instruction", "data", "trace"}
+
+func collect(info *models.SystemInfo) error {
+	var errs []error
+	if model, err := readProcessorName(); err != nil {
+		errs = append(errs, err)
+	} else if model != "" {
+		info.CPUModel = model
+	}
+	if err := readProcessorInfo(info); err != nil {
+		errs = append(errs, err)
+	}
+	if err := readMemoryStatus(info); err != nil {
+		errs = append(errs, err)
+	}
+	readLargePages(info)
+	return errors.Join(errs...)
+}
+
+// readProcessorName 从注册表读取 CPU 型号
+func readProcessorName() (string, error) {
+	var key syscall.Handle
+	path, _ := syscall.UTF16PtrFromString(processorKey)
+	if err := syscall.RegOpenKeyEx(syscall.HKEY_LOCAL_MACHINE, path, 0, syscall.KEY_READ, &key); err != nil {
+		return "", fmt.Errorf("读取 CPU 型号失败: %w", err)
+	}
+	defer syscall.RegCloseKey(key)
+
+	name, _ := syscall.UTF16PtrFromString("ProcessorNameString")
+	buf := make([]uint16, 256)
+	size := uint32(len(buf) * 2)
+	var valueType uint32
+	if err := syscall.RegQueryValueEx(key, name, nil, &valueType, (*byte)(unsafe.Pointer(&buf[0])), &size); err != nil {
+		return "", fmt.Errorf("读取 CPU 型号失败: %w", err)
+	}
+	return strings.TrimSpace(syscall.UTF16ToString(buf)), nil
+}
+
+// readProcessorInfo 通过 GetLogicalProcessorInformation 统计物理核心、缓存与 NUMA 节点
+func readProcessorInfo(info *models.SystemInfo) error {
+	var length uint32
+	procGetLogicalProcessorInformation.Call(0, uintptr(unsafe.Pointer(&length)))
+	itemSize := uint32(unsafe.Sizeof(logicalProcessorInfo{}))
+	if length < itemSize {
+		return fmt.Errorf("读取处理器信息失败")
+	}
+	items := make([]logicalProcessorInfo, length/itemSize)
+	r, _, err := procGetLogicalProcessorInformation.Call(
+		uintptr(unsafe.Pointer(&items[0])),
+		uintptr(unsafe.Pointer(&length)),
+	)
+	if r == 0 {
+		return fmt.Errorf("读取处理器信息失败: %w", err)
+	}
+
+	caches := make(cacheSet)
+	cores, nodes := 0, 0
+	for _, item := range items[:length/itemSize] {
+		switch item.Relationship {
+		case relationProcessorCore:
+			cores++
+		case relationNumaNode:
+			nodes++
+		case relationCache:
+			cache := (*cacheDescriptor)(unsafe.Pointer(&item.Data))
+			cacheType := "unified"
+			if int(cache.Type) < len(cacheTypes) {
+				cacheType = cacheTypes[cache.Type]
+			}
+			caches.add(int(cache.Level), cacheType, uint64(cache.Size))
+		}
+	}
+	info.CPUPhysicalCores = cores
+	if nodes > 0 {
+		info.NUMANodes = nodes
+	}
+	info.CPUCaches = caches.list()
+	return nil
+}
+
+// readMemoryStatus 通过 GlobalMemoryStatusEx 读取物理内存
+func readMemoryStatus(info *models.SystemInfo) error {
+	status := memoryStatusEx{}
+	status.Length = uint32(unsafe.Sizeof(status))
+	r, _, err := procGlobalMemoryStatusEx.Call(uintptr(unsafe.Pointer(&status)))
+	if r == 0 {
+		return fmt.Errorf("读取内存信息失败: %w", err)
+	}
+	info.TotalMemory = status.TotalPhys
+	info.FreeMemory = status.AvailPhys
+	return nil
+}
+
+// readLargePages 读取大页大小，并检查当前用户是否拥有锁定内存页权限，
+// 没有该权限时 XMRig 无法使用大页
+func readLargePages(info *models.SystemInfo) {
+	size, _, _ := procGetLargePageMinimum.Call()
+	if size == 0 {
+		return
+	}
+	info.HugePages.Supported = true
+	info.HugePages.PageSize = uint64(size)
+	info.HugePages.Available = hasPrivilege(lockMemoryPrivilege)
+}
+
+// hasPrivilege 检查当前进程令牌中是否包含指定权限（不要求已启用）
+func hasPrivilege(name string) bool {
+	var target luidAndAttributes
+	namePtr, _ := syscall.UTF16PtrFromString(name)
+	r, _, _ := procLookupPrivilegeValue.Call(0, uintptr(unsafe.Pointer(namePtr)), uintptr(unsafe.Pointer(&target)))
+	if r == 0 {
+		return false
+	}
+
+	process, err := syscall.GetCurrentProcess()
+	if err != nil {
+		return false
+	}
+	var token syscall.Token
+	if err := syscall.OpenProcessToken(process, syscall.TOKEN_QUERY, &token); err != nil {
+		return false
+	}
+	defer token.Close()
+
+	var length uint32
+	_ = syscall.GetTokenInformation(token, tokenPrivileges, nil, 0, &length)
+	if length < 4 {
+		return false
+	}
+	buf := make([]byte, length)
+	if err := syscall.GetTokenInformation(token, tokenPrivileges, &buf[0], length, &length); err != nil {
+		return false
+	}
+	count := *(*uint32)(unsafe.Pointer(&buf[0]))
+	entrySize := unsafe.Sizeof(luidAndAttributes{})
+	for i := uintptr(0); i < uintptr(count); i++ {
+		offset := 4 + i*entrySize
+		if offset+entrySize > uintptr(len(buf)) {
+			break
+		}
+		entry := (*luidAndAttributes)(unsafe.Pointer(&buf[offset]))
+		if entry.LowPart == target.LowPart && entry.HighPart == target.HighPart {
+			return true
+		}
+	}
+	return false
+}
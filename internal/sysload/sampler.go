@@ -0,0 +1,67 @@
+// Package sysload 采样系统 CPU 使用率，并扣除指定进程（矿工）自身的占用，
+// 用于判断用户或其他程序是否正在使用电脑。
+package sysload
+
+import "errors"
+
+// ErrUnsupported 当前系统不支持负载采样
+var ErrUnsupported = errors.New("当前系统不支持采样 CPU 负载")
+
+// times 一次采样的累计 CPU 时间，单位由平台决定，只用于计算差值
+type times struct {
+	total   uint64 // 全部 CPU 的总时间（含空闲）
+	busy    uint64 // 全部 CPU 的非空闲时间
+	process uint64 // 指定进程占用的 CPU 时间
+}
+
+// Sampler 计算两次采样之间其他进程的 CPU 占用
+type Sampler struct {
+	prev    times
+	prevPID int
+	hasPrev bool
+}
+
+// NewSampler 创建采样器
+func NewSampler() *Sampler {
+	return &Sampler{}
+}
+
+// Sample 返回自上次采样以来除 pid 外其他进程占用的 CPU 百分比（0-100，按全部逻辑核归一）。
+// 首次采样或 pid 变化时只记录基准，ok 为 false
+func (s *Sampler) Sample(pid int) (load float64, ok bool, err error) {
+	cur, err := readTimes(pid)
+	if err != nil {
+		s.hasPrev = false
+		return 0, false, err
+	}
+	prev, hadPrev := s.prev, s.hasPrev && s.prevPID == pid
+	s.prev, s.prevPID, s.hasPrev = cur, pid, true
+	if !hadPrev || cur.total <= prev.total {
+		return 0, false, nil
+	}
+
+	total := float64(cur.total - prev.total)
+	busy := float64(sub(cur.busy, prev.busy))
+	process := float64(sub(cur.process, prev.process))
+	other := busy - process
+	if other < 0 {
+		other = 0
+	}
+	load = other / total * 100
+	if load > 100 {
+		load = 100
+	}
+	return load, true, nil
+}
+
+// Reset 丢弃基准，下次采样重新开始
+func (s *Sampler) Reset() {
+	s.hasPrev = false
+}
+
+func sub(a, b uint64) uint64 {
+	if a < b {
+		return 0
+	}
+	return a - b
+}
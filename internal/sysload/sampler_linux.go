@@ -0,0 +1,77 @@
+//go:build linux
+
+package sysload
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// readTimes 读取 /proc/stat 的汇总 CPU 时间与 /proc/<pid>/stat 的进程时间，单位为时钟节拍
+func readTimes(pid int) (times, error) {
+	var t times
+	f, err := os.Open("/proc/stat")
+	if err != nil {
+		return t, fmt.Errorf("读取 /proc/stat 失败: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 || fields[0] != "cpu" {
+			continue
+		}
+		// user nice system idle iowait irq softirq steal，guest 已计入 user
+		var values [8]uint64
+		for i := 1; i < len(fields) && i <= len(values); i++ {
+			values[i-1], _ = strconv.ParseUint(fields[i], 10, 64)
+		}
+		for _, v := range values {
+			t.total += v
+		}
+		idle := values[3] + values[4]
+		t.busy = t.total - idle
+		break
+	}
+	if t.total == 0 {
+		return t, fmt.Errorf("解析 /proc/stat 失败")
+	}
+
+	if pid > 0 {
+		process, err := readProcessTicks(pid)
+		if err != nil {
+			return t, err
+		}
+		t.process = process
+	}
+	return t, nil
+}
+
+// readProcessTicks 读取进程的 utime+stime
+func readProcessTicks(pid int) (uint64, error) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return 0, fmt.Errorf("读取进程状态失败: %w", err)
+	}
+	// 进程名可能包含空格与括号，从最后一个 ')' 之后开始解析
+	s := string(data)
+	idx := strings.LastIndexByte(s, ')')
+	if idx == -1 {
+		return 0, fmt.Errorf("解析进程状态失败")
+	}
+	fields := strings.Fields(s[idx+1:])
+	// 去掉 pid 与进程名后，utime、stime 为第 12、13 个字段
+	if len(fields) < 13 {
+		return 0, fmt.Errorf("解析进程状态失败")
+	}
+	utime, err1 := strconv.ParseUint(fields[11], 10, 64)
+	stime, err2 := strconv.ParseUint(fields[12], 10, 64)
+	if err1 != nil || err2 != nil {
+		return 0, fmt.Errorf("解析进程状态失败")
+	}
+	return utime + stime, nil
+}
@@ -0,0 +1,7 @@
+//go:build !linux && !windows
+
+package sysload
+
+func readTimes(pid int) (times, error) {
+	return times{}, ErrUnsupported
+}
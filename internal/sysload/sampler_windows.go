@@ -0,0 +1,48 @@
+//go:build windows
+
+package sysload
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+const processQueryLimitedInformation = 0x1000
+
+var procGetSystemTimes = syscall.NewLazyDLL("kernel32.dll").NewProc("GetSystemTimes")
+
+// readTimes 通过 GetSystemTimes 与 GetProcessTimes 读取 CPU 时间，单位为 100 纳秒
+func readTimes(pid int) (times, error) {
+	var t times
+	var idle, kernel, user syscall.Filetime
+	r, _, err := procGetSystemTimes.Call(
+		uintptr(unsafe.Pointer(&idle)),
+		uintptr(unsafe.Pointer(&kernel)),
+		uintptr(unsafe.Pointer(&user)),
+	)
+	if r == 0 {
+		return t, fmt.Errorf("GetSystemTimes 失败: %w", err)
+	}
+	// 内核时间包含空闲时间
+	t.total = filetime(kernel) + filetime(user)
+	t.busy = t.total - filetime(idle)
+
+	if pid > 0 {
+		h, err := syscall.OpenProcess(processQueryLimitedInformation, false, uint32(pid))
+		if err != nil {
+			return t, fmt.Errorf("打开进程失败: %w", err)
+		}
+		defer syscall.CloseHandle(h)
+		var creation, exit, pkernel, puser syscall.Filetime
+		if err := syscall.GetProcessTimes(h, &creation, &exit, &pkernel, &puser); err != nil {
+			return t, fmt.Errorf("GetProcessTimes 失败: %w", err)
+		}
+		t.process = filetime(pkernel) + filetime(puser)
+	}
+	return t, nil
+}
+
+func filetime(ft syscall.Filetime) uint64 {
+	return uint64(ft.HighDateTime)<<32 | uint64(ft.LowDateTime)
+}
@@ -0,0 +1,126 @@
+// Package xdag 提供 XDAG 钱包地址的解析与校验
+package xdag
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// XDAG 地址为 20 字节公钥哈希加 4 字节双 SHA-256 校验和，经 Base58 编码
+const (
+	hashLength     = 20
+	checksumLength = 4
+)
+
+// maxWorkerLength 矿机名最大长度
+const maxWorkerLength = 64
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var base58Index = func() [256]int {
+	var idx [256]int
+	for i := range idx {
+		idx[i] = -1
+	}
+	for i := 0; i < len(base58Alphabet); i++ {
+		idx[base58Alphabet[i]] = i
+	}
+	return idx
+}()
+
+// User 矿池用户名解析结果，格式为 地址 或 地址.矿机名
+type User struct {
+	Address string
+	Worker  string
+}
+
+// ParseUser 拆分矿池用户名中的钱包地址与矿机名，并校验两者格式
+func ParseUser(raw string) (*User, error) {
+	s := strings.TrimSpace(raw)
+	if s == "" {
+		return nil, fmt.Errorf("钱包地址不能为空")
+	}
+
+	u := &User{Address: s}
+	if idx := strings.IndexByte(s, '.'); idx != -1 {
+		u.Address, u.Worker = s[:idx], s[idx+1:]
+		if err := validateWorker(u.Worker); err != nil {
+			return nil, err
+		}
+	}
+	if err := ValidateAddress(u.Address); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// String 还原为矿池用户名
+func (u *User) String() string {
+	if u.Worker == "" {
+		return u.Address
+	}
+	return u.Address + "." + u.Worker
+}
+
+// ValidateAddress 校验 XDAG 钱包地址的 Base58 编码、长度与校验和
+func ValidateAddress(addr string) error {
+	if addr == "" {
+		return fmt.Errorf("钱包地址不能为空")
+	}
+	data, err := decodeBase58(addr)
+	if err != nil {
+		return err
+	}
+	if len(data) != hashLength+checksumLength {
+		return fmt.Errorf("钱包地址长度不正确，请确认是完整的 XDAG 地址")
+	}
+	body, sum := data[:hashLength], data[hashLength:]
+	if !bytes.Equal(checksum(body), sum) {
+		return fmt.Errorf("钱包地址校验和错误，请检查是否抄错")
+	}
+	return nil
+}
+
+func validateWorker(worker string) error {
+	if worker == "" {
+		return fmt.Errorf("矿机名不能为空，请去掉地址后的 \".\" 或填写矿机名")
+	}
+	if len(worker) > maxWorkerLength {
+		return fmt.Errorf("矿机名不能超过 %d 个字符", maxWorkerLength)
+	}
+	for _, r := range worker {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return fmt.Errorf("矿机名 %q 只能包含字母、数字、- 和 _", worker)
+		}
+	}
+	return nil
+}
+
+func checksum(body []byte) []byte {
+	first := sha256.Sum256(body)
+	second := sha256.Sum256(first[:])
+	return second[:checksumLength]
+}
+
+func decodeBase58(s string) ([]byte, error) {
+	n := new(big.Int)
+	radix := big.NewInt(int64(len(base58Alphabet)))
+	for _, r := range s {
+		if r >= 256 || base58Index[r] < 0 {
+			return nil, fmt.Errorf("钱包地址包含非法字符 %q", r)
+		}
+		v := base58Index[r]
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(v)))
+	}
+
+	// 前导字符 '1' 对应前导零字节
+	zeros := 0
+	for zeros < len(s) && s[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
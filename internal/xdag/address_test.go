@@ -0,0 +1,93 @@
+package xdag
+
+import (
+	"strings"
+	"testing"
+)
+
+// defaultAddress 程序默认的捐赠钱包地址
+const defaultAddress = "NNZabJQEhrQGTPabqABWVK9v3rSsNQ7Sy"
+
+// replaceAt 替换第 i 个字符
+func replaceAt(s string, i int, c byte) string {
+	b := []byte(s)
+	b[i] = c
+	return string(b)
+}
+
+func TestValidateAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		addr    string
+		wantErr string
+	}{
+		{name: "默认地址", addr: defaultAddress},
+		{name: "空地址", addr: "", wantErr: "不能为空"},
+		{name: "末位抄错", addr: replaceAt(defaultAddress, len(defaultAddress)-1, 'z'), wantErr: "校验和"},
+		{name: "中间一位抄错", addr: replaceAt(defaultAddress, 10, 'q'), wantErr: "校验和"},
+		// 33 位与 32 位的 Base58 都可能解码为 24 字节，少一位由校验和发现
+		{name: "少一位", addr: defaultAddress[:len(defaultAddress)-1], wantErr: "校验和"},
+		{name: "少三位", addr: defaultAddress[:len(defaultAddress)-3], wantErr: "长度"},
+		{name: "多一位", addr: defaultAddress + "1", wantErr: "长度"},
+		{name: "过短", addr: "NNZab", wantErr: "长度"},
+		{name: "包含 0", addr: replaceAt(defaultAddress, 5, '0'), wantErr: "非法字符"},
+		{name: "包含 O", addr: replaceAt(defaultAddress, 5, 'O'), wantErr: "非法字符"},
+		{name: "包含 I", addr: replaceAt(defaultAddress, 5, 'I'), wantErr: "非法字符"},
+		{name: "包含 l", addr: replaceAt(defaultAddress, 5, 'l'), wantErr: "非法字符"},
+		{name: "包含中文", addr: defaultAddress[:32] + "中", wantErr: "非法字符"},
+		{name: "以太坊地址", addr: "0x52908400098527886E0F7030069857D2E4169EE7", wantErr: "非法字符"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAddress(tt.addr)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("得到错误 %v，期望通过", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("得到 %v，期望包含 %q 的错误", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseUser(t *testing.T) {
+	tests := []struct {
+		raw        string
+		wantAddr   string
+		wantWorker string
+		wantErr    bool
+	}{
+		{raw: defaultAddress, wantAddr: defaultAddress},
+		{raw: "  " + defaultAddress + ".rig1  ", wantAddr: defaultAddress, wantWorker: "rig1"},
+		{raw: defaultAddress + ".home-pc_2", wantAddr: defaultAddress, wantWorker: "home-pc_2"},
+		{raw: "", wantErr: true},
+		{raw: defaultAddress + ".", wantErr: true},
+		{raw: defaultAddress + ".rig 1", wantErr: true},
+		{raw: defaultAddress + ".rig.1", wantErr: true},
+		{raw: defaultAddress + "." + strings.Repeat("a", maxWorkerLength+1), wantErr: true},
+		{raw: replaceAt(defaultAddress, 0, 'M') + ".rig1", wantErr: true},
+		{raw: "x", wantErr: true},
+	}
+	for _, tt := range tests {
+		u, err := ParseUser(tt.raw)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%q: 得到 %+v，期望错误", tt.raw, u)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: %v", tt.raw, err)
+			continue
+		}
+		if u.Address != tt.wantAddr || u.Worker != tt.wantWorker {
+			t.Errorf("%q: 得到 地址=%s 矿机=%s，期望 地址=%s 矿机=%s", tt.raw, u.Address, u.Worker, tt.wantAddr, tt.wantWorker)
+		}
+		if want := strings.TrimSpace(tt.raw); u.String() != want {
+			t.Errorf("%q: 还原得到 %s，期望 %s", tt.raw, u.String(), want)
+		}
+	}
+}
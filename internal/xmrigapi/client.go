@@ -0,0 +1,194 @@
+// Package xmrigapi 是 XMRig HTTP API 的客户端，
+// 覆盖 /1/summary、/2/backends、/1/config、/1/threads 与 JSON-RPC 暂停/恢复接口。
+package xmrigapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-wails/internal/models"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultTimeout 默认请求超时
+const defaultTimeout = 3 * time.Second
+
+// APIError XMRig API 返回的错误
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	switch e.StatusCode {
+	case http.StatusUnauthorized:
+		return "XMRig API 拒绝访问，请检查 access-token"
+	case http.StatusForbidden:
+		return "XMRig API 处于只读模式，请关闭 restricted"
+	}
+	if e.Message != "" {
+		return fmt.Sprintf("XMRig API 错误 %d: %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("XMRig API 错误 %d", e.StatusCode)
+}
+
+// Client XMRig HTTP API 客户端
+type Client struct {
+	baseURL     string
+	accessToken string
+	HTTPClient  *http.Client
+}
+
+// New 创建客户端，baseURL 形如 http://127.0.0.1:3649
+func New(baseURL, accessToken string) *Client {
+	return &Client{
+		baseURL:     strings.TrimRight(baseURL, "/"),
+		accessToken: accessToken,
+		HTTPClient:  &http.Client{Timeout: defaultTimeout},
+	}
+}
+
+// NewFromConfig 根据 XMRig 的 http 配置创建客户端
+func NewFromConfig(cfg models.HTTPConfig) *Client {
+	host := cfg.Host
+	switch host {
+	case "", "0.0.0.0":
+		host = "127.0.0.1"
+	case "::":
+		host = "::1"
+	}
+	token := ""
+	if cfg.AccessToken != nil {
+		token = *cfg.AccessToken
+	}
+	return New("http://"+net.JoinHostPort(host, strconv.Itoa(cfg.Port)), token)
+}
+
+// Summary 获取运行概况 GET /1/summary
+func (c *Client) Summary(ctx context.Context) (*Summary, error) {
+	var summary Summary
+	if err := c.do(ctx, http.MethodGet, "/1/summary", nil, &summary); err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+// Backends 获取各计算后端状态 GET /2/backends
+func (c *Client) Backends(ctx context.Context) ([]Backend, error) {
+	var backends []Backend
+	if err := c.do(ctx, http.MethodGet, "/2/backends", nil, &backends); err != nil {
+		return nil, err
+	}
+	return backends, nil
+}
+
+// Threads 获取线程状态 GET /1/threads（旧版本 XMRig）
+func (c *Client) Threads(ctx context.Context) (*Threads, error) {
+	var threads Threads
+	if err := c.do(ctx, http.MethodGet, "/1/threads", nil, &threads); err != nil {
+		return nil, err
+	}
+	return &threads, nil
+}
+
+// Config 获取运行中的完整配置 GET /1/config，需要关闭 restricted
+func (c *Client) Config(ctx context.Context) (json.RawMessage, error) {
+	var raw json.RawMessage
+	if err := c.do(ctx, http.MethodGet, "/1/config", nil, &raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+// UpdateConfig 替换运行中的配置 PUT /1/config，XMRig 会立即应用
+func (c *Client) UpdateConfig(ctx context.Context, config interface{}) error {
+	return c.do(ctx, http.MethodPut, "/1/config", config, nil)
+}
+
+// Pause 暂停挖矿
+func (c *Client) Pause(ctx context.Context) error {
+	return c.rpc(ctx, "pause")
+}
+
+// Resume 恢复挖矿
+func (c *Client) Resume(ctx context.Context) error {
+	return c.rpc(ctx, "resume")
+}
+
+// rpcRequest JSON-RPC 请求
+type rpcRequest struct {
+	ID      int    `json:"id"`
+	JSONRPC string `json:"jsonrpc"`
+	Method  string `json:"method"`
+}
+
+// rpcResponse JSON-RPC 响应
+type rpcResponse struct {
+	ID     int             `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// rpc 调用 POST /json_rpc
+func (c *Client) rpc(ctx context.Context, method string) error {
+	var resp rpcResponse
+	req := rpcRequest{ID: 1, JSONRPC: "2.0", Method: method}
+	if err := c.do(ctx, http.MethodPost, "/json_rpc", req, &resp); err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return fmt.Errorf("XMRig %s 失败: %s (%d)", method, resp.Error.Message, resp.Error.Code)
+	}
+	return nil
+}
+
+// do 发送请求并解码响应
+func (c *Client) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("序列化请求失败: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.accessToken)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return &APIError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(msg))}
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil && err != io.EOF {
+		return fmt.Errorf("解析 %s 响应失败: %w", path, err)
+	}
+	return nil
+}
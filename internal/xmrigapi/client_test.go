@@ -0,0 +1,194 @@
+package xmrigapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const summaryJSON = `{
+	"id": "92f3104f9a2ee78c",
+	"worker_id": "rig1",
+	"uptime": 60,
+	"restricted": false,
+	"results": {"diff_current": 100001, "shares_good": 7, "shares_total": 8},
+	"algo": "rx/0",
+	"connection": {"pool": "pool.example.com:3333", "ping": 42, "accepted": 7, "rejected": 1},
+	"version": "6.21.0",
+	"paused": true,
+	"hashrate": {"total": [null, 1234.5, 1200], "highest": 1300, "threads": [[100.5, null, null]]},
+	"hugepages": [4, 8]
+}`
+
+// newServer 启动测试服务器，handler 中检查请求
+func newServer(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return New(srv.URL+"/", "secret")
+}
+
+func TestSummary(t *testing.T) {
+	client := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/1/summary" {
+			t.Errorf("请求 %s %s，期望 GET /1/summary", r.Method, r.URL.Path)
+		}
+		io.WriteString(w, summaryJSON)
+	})
+
+	summary, err := client.Summary(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.WorkerID != "rig1" || summary.Version != "6.21.0" || !summary.Paused {
+		t.Errorf("概况字段错误: %+v", summary)
+	}
+	if summary.Connection.Pool != "pool.example.com:3333" || summary.Connection.Rejected != 1 {
+		t.Errorf("连接字段错误: %+v", summary.Connection)
+	}
+	if summary.Results.SharesGood != 7 || summary.Results.SharesTotal != 8 {
+		t.Errorf("份额字段错误: %+v", summary.Results)
+	}
+	// 10 秒窗口为 null 时取 60 秒窗口
+	if got := summary.Hashrate.Window(Window10s); got != 0 {
+		t.Errorf("10 秒算力得到 %v，期望 0", got)
+	}
+	if got := summary.Hashrate.Current(); got != 1234.5 {
+		t.Errorf("当前算力得到 %v，期望 1234.5", got)
+	}
+	if got := summary.Hashrate.HighestValue(); got != 1300 {
+		t.Errorf("最高算力得到 %v，期望 1300", got)
+	}
+	if string(summary.Hugepages) != "[4, 8]" {
+		t.Errorf("大页字段得到 %s", summary.Hugepages)
+	}
+}
+
+func TestUpdateConfig(t *testing.T) {
+	var got map[string]interface{}
+	client := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/1/config" {
+			t.Errorf("请求 %s %s，期望 PUT /1/config", r.Method, r.URL.Path)
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type 得到 %q", ct)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("解析请求体失败: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	config := map[string]interface{}{
+		"pools": []interface{}{map[string]interface{}{"url": "b.example.com:3333"}},
+	}
+	if err := client.UpdateConfig(context.Background(), config); err != nil {
+		t.Fatal(err)
+	}
+	pools, _ := got["pools"].([]interface{})
+	if len(pools) != 1 || pools[0].(map[string]interface{})["url"] != "b.example.com:3333" {
+		t.Errorf("服务器收到 %v", got)
+	}
+}
+
+func TestPauseResume(t *testing.T) {
+	var methods []string
+	client := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/json_rpc" {
+			t.Errorf("请求 %s %s，期望 POST /json_rpc", r.Method, r.URL.Path)
+		}
+		var req rpcRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("解析请求体失败: %v", err)
+		}
+		if req.JSONRPC != "2.0" {
+			t.Errorf("jsonrpc 得到 %q", req.JSONRPC)
+		}
+		methods = append(methods, req.Method)
+		if req.Method == "resume" && len(methods) > 2 {
+			io.WriteString(w, `{"id":1,"jsonrpc":"2.0","error":{"code":-32601,"message":"Method not found"}}`)
+			return
+		}
+		io.WriteString(w, `{"id":1,"jsonrpc":"2.0","result":{"status":"OK"}}`)
+	})
+
+	ctx := context.Background()
+	if err := client.Pause(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Resume(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(methods) != 2 || methods[0] != "pause" || methods[1] != "resume" {
+		t.Errorf("调用顺序得到 %v", methods)
+	}
+	// JSON-RPC 层的错误也要返回
+	if err := client.Resume(ctx); err == nil {
+		t.Error("JSON-RPC 错误未返回")
+	}
+}
+
+func TestAuthorizationHeader(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+		want  string
+	}{
+		{name: "设置令牌", token: "secret", want: "Bearer secret"},
+		{name: "未设置令牌", token: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Get("Authorization")
+				io.WriteString(w, `{}`)
+			}))
+			defer srv.Close()
+
+			if _, err := New(srv.URL, tt.token).Summary(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Authorization 得到 %q，期望 %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantMsg string
+	}{
+		{name: "令牌错误", status: http.StatusUnauthorized, wantMsg: "XMRig API 拒绝访问，请检查 access-token"},
+		{name: "只读模式", status: http.StatusForbidden, wantMsg: "XMRig API 处于只读模式，请关闭 restricted"},
+		{name: "服务器错误", status: http.StatusInternalServerError, body: "internal error\n", wantMsg: "XMRig API 错误 500: internal error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				io.WriteString(w, tt.body)
+			})
+
+			_, err := client.Summary(context.Background())
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("得到 %v，期望 *APIError", err)
+			}
+			if apiErr.StatusCode != tt.status {
+				t.Errorf("状态码得到 %d，期望 %d", apiErr.StatusCode, tt.status)
+			}
+			if err.Error() != tt.wantMsg {
+				t.Errorf("错误信息得到 %q，期望 %q", err.Error(), tt.wantMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package xmrigapi
+
+import "encoding/json"
+
+// Summary /1/summary 响应
+type Summary struct {
+	ID          string          `json:"id"`
+	WorkerID    string          `json:"worker_id"`
+	Uptime      int64           `json:"uptime"`
+	Restricted  bool            `json:"restricted"`
+	Resources   Resources       `json:"resources"`
+	Features    []string        `json:"features"`
+	Results     Results         `json:"results"`
+	Algo        string          `json:"algo"`
+	Connection  Connection      `json:"connection"`
+	Version     string          `json:"version"`
+	Kind        string          `json:"kind"`
+	UA          string          `json:"ua"`
+	CPU         CPU             `json:"cpu"`
+	DonateLevel int             `json:"donate_level"`
+	Paused      bool            `json:"paused"`
+	Algorithms  []string        `json:"algorithms"`
+	Hashrate    Hashrate        `json:"hashrate"`
+	Hugepages   json.RawMessage `json:"hugepages"` // 布尔值或 [已分配, 总数]
+}
+
+// Resources 资源占用
+type Resources struct {
+	Memory struct {
+		Free              uint64 `json:"free"`
+		Total             uint64 `json:"total"`
+		ResidentSetMemory uint64 `json:"resident_set_memory"`
+	} `json:"memory"`
+	LoadAverage         []float64 `json:"load_average"`
+	HardwareConcurrency int       `json:"hardware_concurrency"`
+	Threads             int       `json:"threads"`
+}
+
+// Results 提交结果统计
+type Results struct {
+	DiffCurrent uint64          `json:"diff_current"`
+	SharesGood  uint64          `json:"shares_good"`
+	SharesTotal uint64          `json:"shares_total"`
+	AvgTime     int64           `json:"avg_time"`
+	AvgTimeMS   int64           `json:"avg_time_ms"`
+	HashesTotal uint64          `json:"hashes_total"`
+	Best        []uint64        `json:"best"`
+	ErrorLog    json.RawMessage `json:"error_log"`
+}
+
+// Connection 矿池连接状态
+type Connection struct {
+	Pool           string  `json:"pool"`
+	IP             string  `json:"ip"`
+	Uptime         int64   `json:"uptime"`
+	UptimeMS       int64   `json:"uptime_ms"`
+	Ping           int64   `json:"ping"`
+	Failures       int     `json:"failures"`
+	TLS            *string `json:"tls"`
+	TLSFingerprint *string `json:"tls-fingerprint"`
+	Algo           string  `json:"algo"`
+	Diff           uint64  `json:"diff"`
+	Accepted       uint64  `json:"accepted"`
+	Rejected       uint64  `json:"rejected"`
+	AvgTime        int64   `json:"avg_time"`
+	AvgTimeMS      int64   `json:"avg_time_ms"`
+	HashesTotal    uint64  `json:"hashes_total"`
+}
+
+// CPU 处理器信息
+type CPU struct {
+	Brand    string   `json:"brand"`
+	Family   int      `json:"family"`
+	Model    int      `json:"model"`
+	Stepping int      `json:"stepping"`
+	ProcInfo int      `json:"proc_info"`
+	AES      bool     `json:"aes"`
+	AVX2     bool     `json:"avx2"`
+	X64      bool     `json:"x64"`
+	Is64Bit  bool     `json:"64_bit"`
+	L2       uint64   `json:"l2"`
+	L3       uint64   `json:"l3"`
+	Cores    int      `json:"cores"`
+	Threads  int      `json:"threads"`
+	Packages int      `json:"packages"`
+	Nodes    int      `json:"nodes"`
+	Backend  string   `json:"backend"`
+	MSR      string   `json:"msr"`
+	Assembly string   `json:"assembly"`
+	Arch     string   `json:"arch"`
+	Flags    []string `json:"flags"`
+}
+
+// Hashrate 算力，XMRig 在数据不足时对应窗口为 null
+type Hashrate struct {
+	Total   []*float64   `json:"total"` // 10秒, 60秒, 15分钟
+	Highest *float64     `json:"highest"`
+	Threads [][]*float64 `json:"threads"`
+}
+
+// 算力统计窗口在 Hashrate.Total 中的下标
+const (
+	Window10s = iota
+	Window60s
+	Window15m
+)
+
+// Window 返回指定窗口的算力，无数据时为 0
+func (h Hashrate) Window(i int) float64 {
+	if i < 0 || i >= len(h.Total) || h.Total[i] == nil {
+		return 0
+	}
+	return *h.Total[i]
+}
+
+// Current 返回最近可用的算力，依次取 10秒、60秒、15分钟窗口
+func (h Hashrate) Current() float64 {
+	for i := range h.Total {
+		if h.Total[i] != nil {
+			return *h.Total[i]
+		}
+	}
+	return 0
+}
+
+// HighestValue 返回最高算力，无数据时为 0
+func (h Hashrate) HighestValue() float64 {
+	if h.Highest == nil {
+		return 0
+	}
+	return *h.Highest
+}
+
+// Backend /2/backends 中的单个计算后端
+type Backend struct {
+	Type      string          `json:"type"`
+	Enabled   bool            `json:"enabled"`
+	Algo      string          `json:"algo"`
+	Profile   string          `json:"profile"`
+	HWAES     bool            `json:"hw-aes"`
+	Priority  int             `json:"priority"`
+	MSR       bool            `json:"msr"`
+	ASM       string          `json:"asm"`
+	Hugepages []uint64        `json:"hugepages"`
+	Memory    uint64          `json:"memory"`
+	Hashrate  []*float64      `json:"hashrate"`
+	Threads   []BackendThread `json:"threads"`
+}
+
+// BackendThread 后端中的单个线程
+type BackendThread struct {
+	Intensity int        `json:"intensity"`
+	Affinity  int        `json:"affinity"`
+	AV        int        `json:"av"`
+	Hashrate  []*float64 `json:"hashrate"`
+}
+
+// Threads /1/threads 响应（旧版本 XMRig）
+type Threads struct {
+	Hugepages []uint64 `json:"hugepages"`
+	Memory    uint64   `json:"memory"`
+	Threads   []struct {
+		Type     string     `json:"type"`
+		Algo     string     `json:"algo"`
+		AV       int        `json:"av"`
+		Affinity int        `json:"affinity"`
+		Priority int        `json:"priority"`
+		Hashrate []*float64 `json:"hashrate"`
+	} `json:"threads"`
+}
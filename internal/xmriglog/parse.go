@@ -0,0 +1,180 @@
+// Package xmriglog 将 XMRig 的控制台输出解析为结构化日志：去除颜色代码，
+// 拆出时间戳、模块标签与消息，并识别份额、新任务、算力等常见行。
+package xmriglog
+
+import (
+	"go-wails/internal/models"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timeLayout XMRig 行首时间戳格式，使用本地时间
+const timeLayout = "2006-01-02 15:04:05.000"
+
+var (
+	// ansiPattern 终端颜色与光标控制序列
+	ansiPattern = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+	// linePattern [时间戳] 标签 消息，标签按固定宽度补齐空格
+	linePattern = regexp.MustCompile(`^\[(\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(?:\.\d{3})?)\]\s+(?:([a-z][a-z0-9]*)\s+)?(.*)$`)
+
+	sharePattern = regexp.MustCompile(`^(accepted|rejected)\s+\((\d+)/(\d+)\)\s+diff\s+(\S+)(?:\s+"([^"]*)")?(?:\s+\((\d+)\s*ms\))?`)
+	jobPattern   = regexp.MustCompile(`^new job from\s+(\S+)\s+diff\s+(\S+)\s+algo\s+(\S+)(?:\s+height\s+(\d+))?`)
+	speedPattern = regexp.MustCompile(`^speed\s+10s/60s/15m\s+(\S+)\s+(\S+)\s+(\S+)\s+(\S*)H/s(?:\s+max\s+(\S+)\s+(\S*)H/s)?`)
+	// netErrorPattern 连接、登录、读写失败，XMRig 随后会断开并重连
+	netErrorPattern = regexp.MustCompile(`(?i)\b(?:login|read|write|connect|dns|tls|socket)\s+error\b`)
+	errorPattern    = regexp.MustCompile(`(?i)\b(?:error|failed)\b`)
+)
+
+// StripANSI 去除颜色代码
+func StripANSI(s string) string {
+	return ansiPattern.ReplaceAllString(s, "")
+}
+
+// Parse 解析一行输出，source 为 stdout 或 stderr，received 为读取到该行的时间
+func Parse(source, raw string, received time.Time) models.LogRecord {
+	line := strings.TrimRight(StripANSI(raw), " \r")
+	r := models.LogRecord{
+		Time:    received.UnixMilli(),
+		Source:  source,
+		Message: strings.TrimSpace(line),
+		Line:    line,
+	}
+	if m := linePattern.FindStringSubmatch(line); m != nil {
+		if t, err := time.ParseInLocation(timeLayout[:len(m[1])], m[1], time.Local); err == nil {
+			r.Time = t.UnixMilli()
+		}
+		r.Tag = m[2]
+		r.Message = strings.TrimSpace(m[3])
+	}
+
+	r.Share = parseShare(r.Message)
+	if r.Share == nil {
+		r.Job = parseJob(r.Message)
+	}
+	if r.Share == nil && r.Job == nil {
+		r.Speed = parseSpeed(r.Message)
+	}
+	r.Level = level(&r)
+	return r
+}
+
+// PoolState 判断该行是否反映矿池连接状态：登录成功或收到任务为已连接，网络错误为断开
+func PoolState(r models.LogRecord) (connected, ok bool) {
+	if r.Tag != "net" {
+		return false, false
+	}
+	if r.Job != nil || strings.HasPrefix(r.Message, "use pool") {
+		return true, true
+	}
+	if netErrorPattern.MatchString(r.Message) || strings.Contains(r.Message, "no active pools") {
+		return false, true
+	}
+	return false, false
+}
+
+// InvalidShare 判断是否为被矿池以无效或低难度拒绝的份额
+func InvalidShare(r models.LogRecord) bool {
+	if r.Share == nil || r.Share.Accepted {
+		return false
+	}
+	reason := strings.ToLower(r.Share.Reason)
+	return strings.Contains(reason, "invalid") || strings.Contains(reason, "low difficulty")
+}
+
+// parseShare 解析 accepted (1/0) diff 100001 (54 ms) 与 rejected (1/1) diff 100001 "Low difficulty share" (54 ms)
+func parseShare(msg string) *models.ShareLog {
+	m := sharePattern.FindStringSubmatch(msg)
+	if m == nil {
+		return nil
+	}
+	s := &models.ShareLog{Accepted: m[1] == "accepted", Reason: m[5]}
+	s.Total, _ = strconv.ParseUint(m[2], 10, 64)
+	s.Rejected, _ = strconv.ParseUint(m[3], 10, 64)
+	s.Diff = parseDiff(m[4])
+	s.LatencyMs, _ = strconv.ParseInt(m[6], 10, 64)
+	return s
+}
+
+// parseJob 解析 new job from host:port diff 100000 algo rx/0 height 12
+func parseJob(msg string) *models.JobLog {
+	m := jobPattern.FindStringSubmatch(msg)
+	if m == nil {
+		return nil
+	}
+	j := &models.JobLog{Pool: m[1], Diff: parseDiff(m[2]), Algo: m[3]}
+	j.Height, _ = strconv.ParseUint(m[4], 10, 64)
+	return j
+}
+
+// parseSpeed 解析 speed 10s/60s/15m 1234.5 1230.0 n/a H/s max 1300.0 H/s，n/a 记为 0
+func parseSpeed(msg string) *models.SpeedLog {
+	m := speedPattern.FindStringSubmatch(msg)
+	if m == nil {
+		return nil
+	}
+	value := func(v, unit string) float64 {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0
+		}
+		return f * unitScale(unit)
+	}
+	return &models.SpeedLog{
+		Hashrate10s: value(m[1], m[4]),
+		Hashrate60s: value(m[2], m[4]),
+		Hashrate15m: value(m[3], m[4]),
+		Highest:     value(m[5], m[6]),
+	}
+}
+
+// parseDiff 解析难度，兼容 K/M/G/T 后缀
+func parseDiff(v string) uint64 {
+	scale := 1.0
+	if n := len(v); n > 0 {
+		if s := unitScale(v[n-1:]); s > 1 {
+			scale = s
+			v = v[:n-1]
+		}
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || f < 0 {
+		return 0
+	}
+	return uint64(f * scale)
+}
+
+func unitScale(prefix string) float64 {
+	switch strings.ToUpper(prefix) {
+	case "K":
+		return 1e3
+	case "M":
+		return 1e6
+	case "G":
+		return 1e9
+	case "T":
+		return 1e12
+	}
+	return 1
+}
+
+// level 按消息内容判断级别：份额被拒与暂停为警告，网络错误与其他错误为错误，未识别的 stderr 输出为错误
+func level(r *models.LogRecord) string {
+	lower := strings.ToLower(r.Message)
+	switch {
+	case r.Share != nil && !r.Share.Accepted:
+		return models.LogWarning
+	case r.Share != nil || r.Job != nil || r.Speed != nil:
+		return models.LogInfo
+	case netErrorPattern.MatchString(r.Message), strings.Contains(lower, "no active pools"):
+		return models.LogError
+	case errorPattern.MatchString(r.Message):
+		return models.LogError
+	case strings.HasPrefix(lower, "paused"):
+		return models.LogWarning
+	case r.Source == "stderr":
+		return models.LogError
+	}
+	return models.LogInfo
+}
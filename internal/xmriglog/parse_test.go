@@ -0,0 +1,199 @@
+package xmriglog
+
+import (
+	"go-wails/internal/models"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	received := time.Date(2026, time.October, 16, 12, 0, 0, 0, time.Local)
+	type poolState struct{ connected, ok bool }
+	tests := []struct {
+		name    string
+		source  string
+		raw     string
+		tag     string
+		level   string
+		message string
+		share   *models.ShareLog
+		job     *models.JobLog
+		speed   *models.SpeedLog
+		pool    poolState
+	}{
+		{
+			name:    "带颜色的份额接受",
+			raw:     "\x1b[1;37m[2026-10-16 11:59:58.123]\x1b[0m \x1b[1;44;37m cpu      \x1b[0m \x1b[1;32maccepted\x1b[0m (12/1) diff 100001 \x1b[90m(54 ms)\x1b[0m",
+			tag:     "cpu",
+			level:   models.LogInfo,
+			message: "accepted (12/1) diff 100001 (54 ms)",
+			share:   &models.ShareLog{Accepted: true, Total: 12, Rejected: 1, Diff: 100001, LatencyMs: 54},
+		},
+		{
+			name:    "份额被拒带原因",
+			raw:     `[2026-10-16 11:59:58.123]  cpu      rejected (12/2) diff 250K "Low difficulty share" (120 ms)`,
+			tag:     "cpu",
+			level:   models.LogWarning,
+			message: `rejected (12/2) diff 250K "Low difficulty share" (120 ms)`,
+			share:   &models.ShareLog{Total: 12, Rejected: 2, Diff: 250000, LatencyMs: 120, Reason: "Low difficulty share"},
+		},
+		{
+			name:    "新任务带高度",
+			raw:     "\x1b[1;37m[2026-10-16 11:59:58.123]\x1b[0m \x1b[1;44;37m net      \x1b[0m \x1b[1;35mnew job\x1b[0m from stratum.xdag.org:23656 diff \x1b[1;37m100001\x1b[0m algo \x1b[1;37mrx/xdag\x1b[0m height \x1b[1;37m2345678\x1b[0m",
+			tag:     "net",
+			level:   models.LogInfo,
+			message: "new job from stratum.xdag.org:23656 diff 100001 algo rx/xdag height 2345678",
+			job:     &models.JobLog{Pool: "stratum.xdag.org:23656", Diff: 100001, Algo: "rx/xdag", Height: 2345678},
+			pool:    poolState{connected: true, ok: true},
+		},
+		{
+			name:    "新任务不带高度",
+			raw:     `[2026-10-16 11:59:58.123]  net      new job from 127.0.0.1:3333 diff 1M algo rx/0`,
+			tag:     "net",
+			level:   models.LogInfo,
+			message: "new job from 127.0.0.1:3333 diff 1M algo rx/0",
+			job:     &models.JobLog{Pool: "127.0.0.1:3333", Diff: 1000000, Algo: "rx/0"},
+			pool:    poolState{connected: true, ok: true},
+		},
+		{
+			name:    "启动初期算力为 n/a",
+			raw:     `[2026-10-16 11:59:58.123]  miner    speed 10s/60s/15m 1234.5 n/a n/a H/s max 1300.0 H/s`,
+			tag:     "miner",
+			level:   models.LogInfo,
+			message: "speed 10s/60s/15m 1234.5 n/a n/a H/s max 1300.0 H/s",
+			speed:   &models.SpeedLog{Hashrate10s: 1234.5, Highest: 1300},
+		},
+		{
+			name:    "算力单位为 kH/s",
+			raw:     "\x1b[1;37m[2026-10-16 11:59:58.123]\x1b[0m \x1b[1;44;37m miner    \x1b[0m speed 10s/60s/15m \x1b[1;36m1.25\x1b[0m \x1b[0;36m1.5\x1b[0m \x1b[0;36m2.0\x1b[0m \x1b[1;36mkH/s\x1b[0m max \x1b[1;36m2.5 kH/s\x1b[0m",
+			tag:     "miner",
+			level:   models.LogInfo,
+			message: "speed 10s/60s/15m 1.25 1.5 2.0 kH/s max 2.5 kH/s",
+			speed:   &models.SpeedLog{Hashrate10s: 1250, Hashrate60s: 1500, Hashrate15m: 2000, Highest: 2500},
+		},
+		{
+			name:    "连接矿池成功",
+			raw:     "\x1b[1;37m[2026-10-16 11:59:58.123]\x1b[0m \x1b[1;44;37m net      \x1b[0m use pool \x1b[1;36mstratum.xdag.org:23656 \x1b[0m\x1b[1;30m1.2.3.4\x1b[0m",
+			tag:     "net",
+			level:   models.LogInfo,
+			message: "use pool stratum.xdag.org:23656 1.2.3.4",
+			pool:    poolState{connected: true, ok: true},
+		},
+		{
+			name:    "登录失败",
+			raw:     `[2026-10-16 11:59:58.123]  net      stratum.xdag.org:23656 login error code: 6`,
+			tag:     "net",
+			level:   models.LogError,
+			message: "stratum.xdag.org:23656 login error code: 6",
+			pool:    poolState{connected: false, ok: true},
+		},
+		{
+			name:    "没有可用矿池",
+			raw:     `[2026-10-16 11:59:58.123]  net      no active pools, stop mining`,
+			tag:     "net",
+			level:   models.LogError,
+			message: "no active pools, stop mining",
+			pool:    poolState{connected: false, ok: true},
+		},
+		{
+			name:    "含 net 与 failed 字样的普通行",
+			raw:     `[2026-10-16 11:59:58.123]  config   configuration saved to: "/home/miner/netdata/xmrig-nofailed/config.json"`,
+			tag:     "config",
+			level:   models.LogInfo,
+			message: `configuration saved to: "/home/miner/netdata/xmrig-nofailed/config.json"`,
+		},
+		{
+			name:    "net 标签的其他消息不改变连接状态",
+			raw:     `[2026-10-16 11:59:58.123]  net      dev donate started`,
+			tag:     "net",
+			level:   models.LogInfo,
+			message: "dev donate started",
+		},
+		{
+			name:    "其他模块的错误",
+			raw:     `[2026-10-16 11:59:58.123]  msr      FAILED TO APPLY MSR MOD, HASHRATE WILL BE LOW`,
+			tag:     "msr",
+			level:   models.LogError,
+			message: "FAILED TO APPLY MSR MOD, HASHRATE WILL BE LOW",
+		},
+		{
+			name:    "暂停挖矿",
+			raw:     `[2026-10-16 11:59:58.123]  miner    paused, press r to resume`,
+			tag:     "miner",
+			level:   models.LogWarning,
+			message: "paused, press r to resume",
+		},
+		{
+			name:    "无时间戳的启动信息",
+			raw:     " * ABOUT        XMRig/6.21.0 gcc/9.4.0\r",
+			level:   models.LogInfo,
+			message: "* ABOUT        XMRig/6.21.0 gcc/9.4.0",
+		},
+		{
+			name:    "未识别的标准错误输出",
+			source:  "stderr",
+			raw:     "Illegal instruction",
+			level:   models.LogError,
+			message: "Illegal instruction",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := tt.source
+			if source == "" {
+				source = "stdout"
+			}
+			r := Parse(source, tt.raw, received)
+			if r.Tag != tt.tag || r.Level != tt.level || r.Message != tt.message || r.Source != source {
+				t.Fatalf("得到 标签=%q 级别=%q 消息=%q 来源=%q，期望 标签=%q 级别=%q 消息=%q 来源=%q",
+					r.Tag, r.Level, r.Message, r.Source, tt.tag, tt.level, tt.message, source)
+			}
+			if !sameValue(r.Share, tt.share) {
+				t.Errorf("份额得到 %+v，期望 %+v", r.Share, tt.share)
+			}
+			if !sameValue(r.Job, tt.job) {
+				t.Errorf("任务得到 %+v，期望 %+v", r.Job, tt.job)
+			}
+			if !sameValue(r.Speed, tt.speed) {
+				t.Errorf("算力得到 %+v，期望 %+v", r.Speed, tt.speed)
+			}
+			if connected, ok := PoolState(r); connected != tt.pool.connected || ok != tt.pool.ok {
+				t.Errorf("连接状态得到 %v/%v，期望 %v/%v", connected, ok, tt.pool.connected, tt.pool.ok)
+			}
+
+			wantTime := received
+			if tt.tag != "" {
+				wantTime = time.Date(2026, time.October, 16, 11, 59, 58, 123e6, time.Local)
+			}
+			if r.Time != wantTime.UnixMilli() {
+				t.Errorf("时间得到 %d，期望 %d", r.Time, wantTime.UnixMilli())
+			}
+		})
+	}
+}
+
+// sameValue 两个指针同为 nil 或指向相等的值
+func sameValue[T comparable](got, want *T) bool {
+	if got == nil || want == nil {
+		return got == want
+	}
+	return *got == *want
+}
+
+func TestInvalidShare(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want bool
+	}{
+		{`[2026-10-16 11:59:58.123]  cpu      rejected (1/1) diff 100001 "Low difficulty share" (54 ms)`, true},
+		{`[2026-10-16 11:59:58.123]  cpu      rejected (1/2) diff 100001 "Invalid job id" (54 ms)`, true},
+		{`[2026-10-16 11:59:58.123]  cpu      rejected (1/3) diff 100001 "Duplicate share" (54 ms)`, false},
+		{`[2026-10-16 11:59:58.123]  cpu      accepted (2/3) diff 100001 (54 ms)`, false},
+		{`[2026-10-16 11:59:58.123]  net      use pool stratum.xdag.org:23656`, false},
+	}
+	for i, tt := range tests {
+		if got := InvalidShare(Parse("stdout", tt.raw, time.Now())); got != tt.want {
+			t.Errorf("第 %d 行得到 %v，期望 %v", i+1, got, tt.want)
+		}
+	}
+}